@@ -8,9 +8,15 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 )
 
-var errDocumentStart = errors.New("already on document start")
+var (
+	errDocumentStart = errors.New("already on document start")
+
+	// errTrailingField indicates fields left over after the value of a line.
+	errTrailingField = fmt.Errorf("%w: trailing field", ErrInvalidSyntax) //nolint:gochecknoglobals
+)
 
 type syntaxError struct {
 	s string
@@ -26,8 +32,14 @@ func (e syntaxError) Error() string {
 }
 
 type baseLexer struct {
-	value string
-	pos   int
+	value     string
+	pos       int
+	lineStart int
+
+	// countedTo and countedLines cache the number of newlines before
+	// countedTo, so that lineNumber counts each newline only once.
+	countedTo    int
+	countedLines int
 }
 
 func (l baseLexer) syntaxError() error {
@@ -210,6 +222,13 @@ func (l *baseLexer) readType() (byte, error) {
 			continue
 		}
 
+		start := l.pos - 1
+		l.lineStart = strings.LastIndexAny(l.value[:start], "\r\n") + 1
+		if l.lineStart != start {
+			// The previous line was not read up to its end.
+			return firstByte, fmt.Errorf("%w `%v`", errTrailingField, l.value[start:l.lineEnd()])
+		}
+
 		secondByte, err := l.readByte()
 		if err != nil {
 			return 0, err
@@ -223,6 +242,41 @@ func (l *baseLexer) readType() (byte, error) {
 	}
 }
 
+// Returns the line currently being lexed, without its line ending.
+func (l baseLexer) currentLine() string {
+	return l.value[l.lineStart:l.lineEnd()]
+}
+
+// Returns the offset of the line ending of the line currently being lexed.
+func (l baseLexer) lineEnd() int {
+	line := l.value[l.lineStart:]
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	return l.lineStart + len(strings.TrimSuffix(line, "\r"))
+}
+
+// Returns the 1-based number of the line currently being lexed.
+func (l *baseLexer) lineNumber() int {
+	if l.lineStart < l.countedTo {
+		l.countedTo, l.countedLines = 0, 0
+	}
+	l.countedLines += strings.Count(l.value[l.countedTo:l.lineStart], "\n")
+	l.countedTo = l.lineStart
+
+	return l.countedLines + 1
+}
+
+// Moves the position past the end of the current line.
+func (l *baseLexer) skipLine() {
+	if i := strings.IndexByte(l.value[l.pos:], '\n'); i >= 0 {
+		l.pos += i + 1
+	} else {
+		l.pos = len(l.value)
+	}
+}
+
 func isNewline(ch byte) bool { return ch == '\n' || ch == '\r' }
 
 func isWhitespace(ch byte) bool { return ch == ' ' || ch == '\t' }
@@ -68,3 +68,16 @@ func TestLexer(t *testing.T) {
 		})
 	})
 }
+
+func TestLexerLineNumber(t *testing.T) {
+	lex := &baseLexer{value: "v=0\nv=1\r\n\nv=2\nv=3"}
+	for _, test := range []struct {
+		lineStart int
+		line      int
+	}{
+		{0, 1}, {4, 2}, {15, 5}, {15, 5}, {10, 4}, {0, 1},
+	} {
+		lex.lineStart = test.lineStart
+		assert.Equal(t, test.line, lex.lineNumber())
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+// Clone returns a deep copy of the session description. The copy shares no
+// pointers or slices with the original, so either can be modified freely.
+func (s *SessionDescription) Clone() *SessionDescription {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.SessionInformation = clonePointer(s.SessionInformation)
+	if s.URI != nil {
+		// url.Userinfo is immutable and may be shared.
+		uri := *s.URI
+		c.URI = &uri
+	}
+	c.EmailAddress = clonePointer(s.EmailAddress)
+	c.PhoneNumber = clonePointer(s.PhoneNumber)
+	c.ConnectionInformation = s.ConnectionInformation.Clone()
+	c.Bandwidth = cloneSlice(s.Bandwidth)
+	if s.TimeDescriptions != nil {
+		c.TimeDescriptions = make([]TimeDescription, len(s.TimeDescriptions))
+		for i, td := range s.TimeDescriptions {
+			c.TimeDescriptions[i] = td.Clone()
+		}
+	}
+	c.TimeZones = cloneSlice(s.TimeZones)
+	c.EncryptionKey = clonePointer(s.EncryptionKey)
+	c.Attributes = cloneSlice(s.Attributes)
+	if s.MediaDescriptions != nil {
+		c.MediaDescriptions = make([]*MediaDescription, len(s.MediaDescriptions))
+		for i, md := range s.MediaDescriptions {
+			c.MediaDescriptions[i] = md.Clone()
+		}
+	}
+
+	return &c
+}
+
+// Clone returns a deep copy of the media description.
+func (d *MediaDescription) Clone() *MediaDescription {
+	if d == nil {
+		return nil
+	}
+
+	c := *d
+	c.MediaName = d.MediaName.Clone()
+	c.MediaTitle = clonePointer(d.MediaTitle)
+	c.ConnectionInformation = d.ConnectionInformation.Clone()
+	c.Bandwidth = cloneSlice(d.Bandwidth)
+	c.EncryptionKey = clonePointer(d.EncryptionKey)
+	c.Attributes = cloneSlice(d.Attributes)
+
+	return &c
+}
+
+// Clone returns a deep copy of the media name.
+func (m MediaName) Clone() MediaName {
+	m.Port = m.Port.Clone()
+	m.Protos = cloneSlice(m.Protos)
+	m.Formats = cloneSlice(m.Formats)
+
+	return m
+}
+
+// Clone returns a deep copy of the port.
+func (p RangedPort) Clone() RangedPort {
+	p.Range = clonePointer(p.Range)
+
+	return p
+}
+
+// Clone returns a deep copy of the connection information.
+func (c *ConnectionInformation) Clone() *ConnectionInformation {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	clone.Address = c.Address.Clone()
+
+	return &clone
+}
+
+// Clone returns a deep copy of the address.
+func (c *Address) Clone() *Address {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	clone.TTL = clonePointer(c.TTL)
+	clone.Range = clonePointer(c.Range)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the time description.
+func (t TimeDescription) Clone() TimeDescription {
+	if t.RepeatTimes != nil {
+		repeatTimes := make([]RepeatTime, len(t.RepeatTimes))
+		for i, r := range t.RepeatTimes {
+			repeatTimes[i] = r.Clone()
+		}
+		t.RepeatTimes = repeatTimes
+	}
+
+	return t
+}
+
+// Clone returns a deep copy of the repeat time.
+func (r RepeatTime) Clone() RepeatTime {
+	r.Offsets = cloneSlice(r.Offsets)
+
+	return r
+}
+
+// DeepClone returns a deep copy of the ExtMap. Clone converts the ExtMap to
+// an Attribute instead.
+func (e *ExtMap) DeepClone() *ExtMap {
+	if e == nil {
+		return nil
+	}
+
+	clone := *e
+	if e.URI != nil {
+		uri := *e.URI
+		clone.URI = &uri
+	}
+	clone.ExtAttr = clonePointer(e.ExtAttr)
+
+	return &clone
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	clone := *p
+
+	return &clone
+}
+
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(s)), s...)
+}
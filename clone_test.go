@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionDescriptionClone(t *testing.T) {
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(CanonicalUnmarshalSDP))
+
+	ttl, addresses, portRange := 127, 2, 2
+	sd.ConnectionInformation.Address.TTL = &ttl
+	sd.ConnectionInformation.Address.Range = &addresses
+	sd.MediaDescriptions[0].MediaName.Port.Range = &portRange
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone)
+
+	*clone.SessionInformation = "changed"
+	clone.URI.Path = "/changed"
+	*clone.EmailAddress = "changed"
+	*clone.PhoneNumber = "changed"
+	*clone.EncryptionKey = "changed"
+	clone.ConnectionInformation.Address.Address = "changed"
+	*clone.ConnectionInformation.Address.TTL = 1
+	*clone.ConnectionInformation.Address.Range = 1
+	clone.Bandwidth[0].Bandwidth = 1
+	clone.TimeDescriptions[1].RepeatTimes[0].Offsets[0] = 1
+	clone.TimeZones[0].Offset = 1
+	clone.Attributes[0].Value = "changed"
+
+	md := clone.MediaDescriptions[0]
+	*md.MediaName.Port.Range = 1
+	md.MediaName.Protos[0] = "changed"
+	md.MediaName.Formats[0] = "changed"
+	*md.MediaTitle = "changed"
+	md.ConnectionInformation.Address.Address = "changed"
+	md.Bandwidth[0].Bandwidth = 1
+	*md.EncryptionKey = "changed"
+	md.Attributes[0].Key = "changed"
+	clone.MediaDescriptions[1] = nil
+
+	original := &SessionDescription{}
+	assert.NoError(t, original.UnmarshalString(CanonicalUnmarshalSDP))
+	original.ConnectionInformation.Address.TTL = &ttl
+	original.ConnectionInformation.Address.Range = &addresses
+	original.MediaDescriptions[0].MediaName.Port.Range = &portRange
+	assert.Equal(t, original, sd)
+}
+
+func TestCloneNil(t *testing.T) {
+	var sd *SessionDescription
+	assert.Nil(t, sd.Clone())
+
+	var md *MediaDescription
+	assert.Nil(t, md.Clone())
+
+	assert.Equal(t, &SessionDescription{}, (&SessionDescription{}).Clone())
+}
+
+func TestExtMapDeepClone(t *testing.T) {
+	uri, err := url.Parse(TransportCCURI)
+	assert.NoError(t, err)
+	attr := "attr"
+	extMap := &ExtMap{Value: 1, URI: uri, ExtAttr: &attr}
+
+	clone := extMap.DeepClone()
+	assert.Equal(t, extMap, clone)
+
+	*clone.ExtAttr = "changed"
+	clone.URI.Host = "changed"
+	assert.Equal(t, "attr", *extMap.ExtAttr)
+	assert.Equal(t, TransportCCURI, extMap.URI.String())
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"sort"
+	"strings"
+)
+
+// CodecMatchScore rates how closely a codec matches a wanted codec. Higher
+// scores are better matches.
+type CodecMatchScore int
+
+const (
+	// CodecMatchNone means the name, clock rate or encoding parameters differ.
+	CodecMatchNone CodecMatchScore = iota
+
+	// CodecMatchName means the name, clock rate and encoding parameters
+	// match but none of the wanted format parameters do.
+	CodecMatchName
+
+	// CodecMatchPartialFmtp means some of the wanted format parameters
+	// match.
+	CodecMatchPartialFmtp
+
+	// CodecMatchCompatibleFmtp means the format parameters differ but can be
+	// negotiated by the rules of the codec, like H264 profile-level-ids with
+	// the same profile.
+	CodecMatchCompatibleFmtp
+
+	// CodecMatchExactFmtp means the format parameters are equivalent.
+	CodecMatchExactFmtp
+)
+
+func (s CodecMatchScore) String() string {
+	switch s {
+	case CodecMatchNone:
+		return "none"
+	case CodecMatchName:
+		return "name"
+	case CodecMatchPartialFmtp:
+		return "partial-fmtp"
+	case CodecMatchCompatibleFmtp:
+		return "compatible-fmtp"
+	case CodecMatchExactFmtp:
+		return "exact-fmtp"
+	default:
+		return "Unknown"
+	}
+}
+
+// CodecMatch is a codec that matches a wanted codec.
+type CodecMatch struct {
+	Codec Codec
+	Score CodecMatchScore
+}
+
+// ScoreCodec rates how closely got matches wanted. Empty fields of wanted
+// match any value, except for Fmtp: a wanted codec without format
+// parameters matches a codec without them exactly and any other by name.
+func ScoreCodec(wanted, got Codec) CodecMatchScore {
+	if (wanted.Name != "" && !strings.EqualFold(wanted.Name, got.Name)) ||
+		(wanted.ClockRate != 0 && wanted.ClockRate != got.ClockRate) ||
+		(wanted.EncodingParameters != "" && wanted.EncodingParameters != got.EncodingParameters) {
+		return CodecMatchNone
+	}
+
+	if equivalentFmtp(wanted.Fmtp, got.Fmtp) {
+		return CodecMatchExactFmtp
+	}
+
+	wantedParams, gotParams := ParseFmtpParams(wanted.Fmtp), ParseFmtpParams(got.Fmtp)
+	if compatible, _ := fmtpCompatible(got.Name, wantedParams, gotParams); compatible {
+		return CodecMatchCompatibleFmtp
+	}
+
+	for _, param := range wantedParams {
+		if value, ok := gotParams.Get(param.Key); param.Key != "" && ok && value == param.Value {
+			return CodecMatchPartialFmtp
+		}
+	}
+
+	return CodecMatchName
+}
+
+// MatchCodecs returns the codecs of the media description that match the
+// wanted codec, best match first. Codecs with the same score keep the order
+// of the formats of the "m=" line, which is the order of preference.
+func (d *MediaDescription) MatchCodecs(wanted Codec) []CodecMatch {
+	return rankCodecs(wanted, d.Codecs())
+}
+
+// MatchCodecs returns the codecs of all media descriptions that match the
+// wanted codec, best match first. Codecs with the same score are ordered by
+// media description and then by the formats of the "m=" line.
+func (s *SessionDescription) MatchCodecs(wanted Codec) []CodecMatch {
+	var codecs []Codec
+	for _, m := range s.MediaDescriptions {
+		codecs = append(codecs, m.Codecs()...)
+	}
+
+	return rankCodecs(wanted, codecs)
+}
+
+func rankCodecs(wanted Codec, codecs []Codec) []CodecMatch {
+	var matches []CodecMatch
+	for _, codec := range codecs {
+		if score := ScoreCodec(wanted, codec); score != CodecMatchNone {
+			matches = append(matches, CodecMatch{Codec: codec, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
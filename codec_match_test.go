@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreCodec(t *testing.T) {
+	got := Codec{Name: "H264", ClockRate: 90000, Fmtp: "profile-level-id=42e01f;packetization-mode=1"}
+
+	for _, test := range []struct {
+		Wanted   Codec
+		Expected CodecMatchScore
+	}{
+		{Codec{Name: "VP8"}, CodecMatchNone},
+		{Codec{Name: "h264", ClockRate: 48000}, CodecMatchNone},
+		{Codec{Name: "h264"}, CodecMatchName},
+		{Codec{Name: "H264", Fmtp: "profile-level-id=640c1f"}, CodecMatchName},
+		// Without profile-level-id the profile is Baseline, which matches
+		// Constrained Baseline.
+		{Codec{Name: "H264", Fmtp: "packetization-mode=1"}, CodecMatchCompatibleFmtp},
+		{Codec{Name: "H264", Fmtp: "packetization-mode=1; profile-level-id=42e01f"}, CodecMatchExactFmtp},
+	} {
+		assert.Equal(t, test.Expected, ScoreCodec(test.Wanted, got), test.Wanted.String())
+	}
+}
+
+func TestMatchCodecs(t *testing.T) {
+	sd := getTestSessionDescription()
+	md := sd.MediaDescriptions[0]
+
+	payloadTypes := func(matches []CodecMatch) (result []uint8) {
+		for _, match := range matches {
+			result = append(result, match.Codec.PayloadType)
+		}
+
+		return result
+	}
+
+	// Formats are 120 121 126 97 98, all H264 codecs match by name.
+	matches := md.MatchCodecs(Codec{Name: "H264", Fmtp: "profile-level-id=42e01f;level-asymmetry-allowed=1"})
+	assert.Equal(t, []uint8{97, 126, 98}, payloadTypes(matches))
+	assert.Equal(t, []CodecMatchScore{CodecMatchExactFmtp, CodecMatchPartialFmtp, CodecMatchName},
+		[]CodecMatchScore{matches[0].Score, matches[1].Score, matches[2].Score})
+
+	// 97 is the only one with the default packetization-mode 0.
+	matches = md.MatchCodecs(Codec{Name: "H264"})
+	assert.Equal(t, []uint8{97, 126, 98}, payloadTypes(matches))
+	assert.Empty(t, md.MatchCodecs(Codec{Name: "AV1"}))
+
+	// The payload type is stable between runs.
+	for i := 0; i < 20; i++ {
+		payloadType, err := sd.GetPayloadTypeForCodec(Codec{Name: "H264"})
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(126), payloadType)
+	}
+
+	sd = getTestAmbiguousSessionDescription()
+	assert.Equal(t, []uint8{0}, payloadTypes(sd.MatchCodecs(Codec{ClockRate: 8000})))
+	assert.Equal(t, []uint8{97, 96}, payloadTypes(sd.MatchCodecs(Codec{ClockRate: 90000})))
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidConnectionRole indicates an unknown "a=setup" value or a
+// combination of offered and answered roles that is not allowed.
+var ErrInvalidConnectionRole = errors.New("sdp: invalid connection role")
+
+// ConnectionRole indicates which of the end points should initiate the connection establishment.
+type ConnectionRole int
+
+const (
+	// ConnectionRoleActive indicates the endpoint will initiate an outgoing connection.
+	ConnectionRoleActive ConnectionRole = iota + 1
+
+	// ConnectionRolePassive indicates the endpoint will accept an incoming connection.
+	ConnectionRolePassive
+
+	// ConnectionRoleActpass indicates the endpoint is willing to accept an incoming connection or
+	// to initiate an outgoing connection.
+	ConnectionRoleActpass
+
+	// ConnectionRoleHoldconn indicates the endpoint does not want the connection to be established for the time being.
+	ConnectionRoleHoldconn
+)
+
+const (
+	connectionRoleActiveStr   = "active"
+	connectionRolePassiveStr  = "passive"
+	connectionRoleActpassStr  = "actpass"
+	connectionRoleHoldconnStr = "holdconn"
+)
+
+// NewConnectionRole defines a procedure for creating a new connection role
+// from a raw "a=setup" value.
+func NewConnectionRole(raw string) (ConnectionRole, error) {
+	switch raw {
+	case connectionRoleActiveStr:
+		return ConnectionRoleActive, nil
+	case connectionRolePassiveStr:
+		return ConnectionRolePassive, nil
+	case connectionRoleActpassStr:
+		return ConnectionRoleActpass, nil
+	case connectionRoleHoldconnStr:
+		return ConnectionRoleHoldconn, nil
+	default:
+		return ConnectionRole(unknown), fmt.Errorf("%w `%v`", ErrInvalidConnectionRole, raw)
+	}
+}
+
+func (t ConnectionRole) String() string {
+	switch t {
+	case ConnectionRoleActive:
+		return connectionRoleActiveStr
+	case ConnectionRolePassive:
+		return connectionRolePassiveStr
+	case ConnectionRoleActpass:
+		return connectionRoleActpassStr
+	case ConnectionRoleHoldconn:
+		return connectionRoleHoldconnStr
+	default:
+		return "Unknown"
+	}
+}
+
+// DTLSRole is the role of an endpoint in the DTLS handshake.
+type DTLSRole int
+
+const (
+	// DTLSRoleClient is the endpoint that sends the ClientHello.
+	DTLSRoleClient DTLSRole = iota + 1
+
+	// DTLSRoleServer is the endpoint that waits for the ClientHello.
+	DTLSRoleServer
+)
+
+func (r DTLSRole) String() string {
+	switch r {
+	case DTLSRoleClient:
+		return "client"
+	case DTLSRoleServer:
+		return "server"
+	default:
+		return "Unknown"
+	}
+}
+
+// AnswerConnectionRoles returns the roles an answerer may choose for the
+// role of the offerer, most preferred first. DTLS does not support
+// holdconn, so offering it is an error.
+// https://datatracker.ietf.org/doc/html/rfc5763#section-5
+// https://datatracker.ietf.org/doc/html/rfc8842#section-5.1
+func AnswerConnectionRoles(offer ConnectionRole) ([]ConnectionRole, error) {
+	switch offer {
+	case ConnectionRoleActpass:
+		return []ConnectionRole{ConnectionRoleActive, ConnectionRolePassive}, nil
+	case ConnectionRoleActive:
+		return []ConnectionRole{ConnectionRolePassive}, nil
+	case ConnectionRolePassive:
+		return []ConnectionRole{ConnectionRoleActive}, nil
+	default:
+		return nil, fmt.Errorf("%w: `%v` in offer", ErrInvalidConnectionRole, offer)
+	}
+}
+
+// NegotiateDTLSRoles checks the roles of an offer and its answer and
+// returns the DTLS roles of the offerer and the answerer. The active
+// endpoint becomes the DTLS client.
+func NegotiateDTLSRoles(offer, answer ConnectionRole) (offerer, answerer DTLSRole, err error) {
+	allowed, err := AnswerConnectionRoles(offer)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, role := range allowed {
+		if role != answer {
+			continue
+		}
+
+		if answer == ConnectionRoleActive {
+			return DTLSRoleServer, DTLSRoleClient, nil
+		}
+
+		return DTLSRoleClient, DTLSRoleServer, nil
+	}
+
+	return 0, 0, fmt.Errorf("%w: `%v` in answer to `%v`", ErrInvalidConnectionRole, answer, offer)
+}
+
+// ConnectionRole returns the role of the "a=setup" attribute of the media
+// description, or zero without an error if there is none.
+func (d *MediaDescription) ConnectionRole() (ConnectionRole, error) {
+	return connectionRole(d.Attributes)
+}
+
+// ConnectionRole returns the role of the session level "a=setup"
+// attribute, or zero without an error if there is none.
+func (s *SessionDescription) ConnectionRole() (ConnectionRole, error) {
+	return connectionRole(s.Attributes)
+}
+
+// MediaConnectionRole returns the connection role that applies to a media
+// description: its own "a=setup" attribute if it has one, otherwise the
+// session level one.
+func (s *SessionDescription) MediaConnectionRole(md *MediaDescription) (ConnectionRole, error) {
+	if md != nil && hasAttribute(md.Attributes, AttrKeyConnectionSetup) {
+		return md.ConnectionRole()
+	}
+
+	return s.ConnectionRole()
+}
+
+func connectionRole(attributes []Attribute) (ConnectionRole, error) {
+	for _, a := range attributes {
+		if a.Key == AttrKeyConnectionSetup {
+			return NewConnectionRole(a.Value)
+		}
+	}
+
+	return ConnectionRole(unknown), nil
+}
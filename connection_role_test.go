@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnectionRole(t *testing.T) {
+	for _, role := range []ConnectionRole{
+		ConnectionRoleActive, ConnectionRolePassive, ConnectionRoleActpass, ConnectionRoleHoldconn,
+	} {
+		parsed, err := NewConnectionRole(role.String())
+		assert.NoError(t, err)
+		assert.Equal(t, role, parsed)
+	}
+
+	role, err := NewConnectionRole("Active")
+	assert.ErrorIs(t, err, ErrInvalidConnectionRole)
+	assert.Equal(t, ConnectionRole(unknown), role)
+	assert.Equal(t, "Unknown", role.String())
+}
+
+func TestNegotiateDTLSRoles(t *testing.T) {
+	for _, test := range []struct {
+		Offer, Answer       ConnectionRole
+		Offerer, Answerer   DTLSRole
+		ExpectedAnswerRoles []ConnectionRole
+	}{
+		{
+			ConnectionRoleActpass, ConnectionRoleActive, DTLSRoleServer, DTLSRoleClient,
+			[]ConnectionRole{ConnectionRoleActive, ConnectionRolePassive},
+		},
+		{
+			ConnectionRoleActpass, ConnectionRolePassive, DTLSRoleClient, DTLSRoleServer,
+			[]ConnectionRole{ConnectionRoleActive, ConnectionRolePassive},
+		},
+		{
+			ConnectionRoleActive, ConnectionRolePassive, DTLSRoleClient, DTLSRoleServer,
+			[]ConnectionRole{ConnectionRolePassive},
+		},
+		{
+			ConnectionRolePassive, ConnectionRoleActive, DTLSRoleServer, DTLSRoleClient,
+			[]ConnectionRole{ConnectionRoleActive},
+		},
+	} {
+		roles, err := AnswerConnectionRoles(test.Offer)
+		assert.NoError(t, err)
+		assert.Equal(t, test.ExpectedAnswerRoles, roles)
+
+		offerer, answerer, err := NegotiateDTLSRoles(test.Offer, test.Answer)
+		assert.NoError(t, err, "%v/%v", test.Offer, test.Answer)
+		assert.Equal(t, test.Offerer, offerer, "%v/%v", test.Offer, test.Answer)
+		assert.Equal(t, test.Answerer, answerer, "%v/%v", test.Offer, test.Answer)
+	}
+
+	for _, test := range [][2]ConnectionRole{
+		{ConnectionRoleActpass, ConnectionRoleActpass},
+		{ConnectionRoleActpass, ConnectionRoleHoldconn},
+		{ConnectionRoleActive, ConnectionRoleActive},
+		{ConnectionRolePassive, ConnectionRolePassive},
+		{ConnectionRoleHoldconn, ConnectionRoleHoldconn},
+		{ConnectionRole(unknown), ConnectionRoleActive},
+	} {
+		_, _, err := NegotiateDTLSRoles(test[0], test[1])
+		assert.ErrorIs(t, err, ErrInvalidConnectionRole, "%v/%v", test[0], test[1])
+	}
+}
+
+func TestMediaConnectionRole(t *testing.T) {
+	audio := NewJSEPMediaDescription("audio", nil)
+	video := NewJSEPMediaDescription("video", nil).WithValueAttribute(AttrKeyConnectionSetup, "passive")
+	sd := (&SessionDescription{}).WithMedia(audio).WithMedia(video)
+
+	role, err := sd.MediaConnectionRole(audio)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRole(unknown), role)
+
+	sd.WithValueAttribute(AttrKeyConnectionSetup, "actpass")
+	role, err = sd.MediaConnectionRole(audio)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRoleActpass, role)
+
+	role, err = sd.MediaConnectionRole(video)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRolePassive, role)
+
+	role, err = video.ConnectionRole()
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRolePassive, role)
+
+	audio.WithValueAttribute(AttrKeyConnectionSetup, "bogus")
+	_, err = sd.MediaConnectionRole(audio)
+	assert.ErrorIs(t, err, ErrInvalidConnectionRole)
+}
@@ -0,0 +1,421 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidCrypto indicates a crypto attribute that does not follow the
+	// grammar of RFC 4568.
+	ErrInvalidCrypto = errors.New("sdp: invalid crypto")
+
+	// ErrUnknownCryptoSuite indicates an SRTP crypto suite that is not
+	// supported.
+	ErrUnknownCryptoSuite = errors.New("sdp: unknown crypto suite")
+
+	// ErrNoMatchingCrypto indicates that none of the offered crypto
+	// attributes uses a supported crypto suite.
+	ErrNoMatchingCrypto = errors.New("sdp: no matching crypto")
+)
+
+// CryptoSuite is the SRTP crypto suite of a crypto attribute.
+type CryptoSuite int
+
+// Crypto suites of the "SRTP Crypto Suite Registrations" registry.
+// https://www.iana.org/assignments/sdp-security-descriptions
+const (
+	CryptoSuiteAESCM128HMACSHA180 CryptoSuite = iota + 1
+	CryptoSuiteAESCM128HMACSHA132
+	CryptoSuiteF8128HMACSHA180
+	CryptoSuiteAES192CMHMACSHA180
+	CryptoSuiteAES192CMHMACSHA132
+	CryptoSuiteAES256CMHMACSHA180
+	CryptoSuiteAES256CMHMACSHA132
+	CryptoSuiteAEADAES128GCM
+	CryptoSuiteAEADAES256GCM
+)
+
+var cryptoSuiteNames = map[CryptoSuite]string{ //nolint:gochecknoglobals
+	CryptoSuiteAESCM128HMACSHA180: "AES_CM_128_HMAC_SHA1_80",
+	CryptoSuiteAESCM128HMACSHA132: "AES_CM_128_HMAC_SHA1_32",
+	CryptoSuiteF8128HMACSHA180:    "F8_128_HMAC_SHA1_80",
+	CryptoSuiteAES192CMHMACSHA180: "AES_192_CM_HMAC_SHA1_80",
+	CryptoSuiteAES192CMHMACSHA132: "AES_192_CM_HMAC_SHA1_32",
+	CryptoSuiteAES256CMHMACSHA180: "AES_256_CM_HMAC_SHA1_80",
+	CryptoSuiteAES256CMHMACSHA132: "AES_256_CM_HMAC_SHA1_32",
+	CryptoSuiteAEADAES128GCM:      "AEAD_AES_128_GCM",
+	CryptoSuiteAEADAES256GCM:      "AEAD_AES_256_GCM",
+}
+
+// ParseCryptoSuite parses the name of a crypto suite. Names are case
+// sensitive.
+func ParseCryptoSuite(name string) (CryptoSuite, error) {
+	for s, n := range cryptoSuiteNames {
+		if n == name {
+			return s, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w `%v`", ErrUnknownCryptoSuite, name)
+}
+
+func (s CryptoSuite) String() string {
+	if name, ok := cryptoSuiteNames[s]; ok {
+		return name
+	}
+
+	return "Unknown"
+}
+
+// KeyLen returns the length of the master key of the crypto suite in bytes,
+// or 0 for an unknown crypto suite.
+func (s CryptoSuite) KeyLen() int {
+	switch s {
+	case CryptoSuiteAESCM128HMACSHA180, CryptoSuiteAESCM128HMACSHA132, CryptoSuiteF8128HMACSHA180,
+		CryptoSuiteAEADAES128GCM:
+		return 16
+	case CryptoSuiteAES192CMHMACSHA180, CryptoSuiteAES192CMHMACSHA132:
+		return 24
+	case CryptoSuiteAES256CMHMACSHA180, CryptoSuiteAES256CMHMACSHA132, CryptoSuiteAEADAES256GCM:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// SaltLen returns the length of the master salt of the crypto suite in
+// bytes, or 0 for an unknown crypto suite.
+// https://datatracker.ietf.org/doc/html/rfc7714#section-12
+func (s CryptoSuite) SaltLen() int {
+	switch s {
+	case CryptoSuiteAEADAES128GCM, CryptoSuiteAEADAES256GCM:
+		return 12
+	default:
+		if s.KeyLen() == 0 {
+			return 0
+		}
+
+		return 14
+	}
+}
+
+// CryptoKeyParam represents an inline key parameter of a crypto attribute.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-6.1
+type CryptoKeyParam struct {
+	// Key is the concatenated master key and master salt.
+	Key []byte
+	// Lifetime is the maximum number of packets protected with the key, 0
+	// if unspecified.
+	Lifetime uint64
+	// MKI is the master key identifier, used only if MKILength is not 0.
+	MKI uint64
+	// MKILength is the length of the MKI field in SRTP packets in bytes.
+	MKILength uint8
+}
+
+func (p *CryptoKeyParam) unmarshal(raw string) error {
+	info, ok := strings.CutPrefix(raw, "inline:")
+	if !ok {
+		return fmt.Errorf("%w: key method `%v`", ErrInvalidCrypto, raw)
+	}
+
+	fields := strings.Split(info, "|")
+	key, err := base64.StdEncoding.DecodeString(fields[0])
+	if err != nil || len(key) == 0 {
+		return fmt.Errorf("%w: key `%v`", ErrInvalidCrypto, fields[0])
+	}
+	param := CryptoKeyParam{Key: key}
+
+	for _, field := range fields[1:] {
+		if mki, length, ok := strings.Cut(field, ":"); ok {
+			if param.MKILength != 0 {
+				return fmt.Errorf("%w: mki `%v`", ErrInvalidCrypto, field)
+			}
+			value, err := strconv.ParseUint(mki, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: mki `%v`", ErrInvalidCrypto, field)
+			}
+			mkiLength, err := strconv.ParseUint(length, 10, 8)
+			if err != nil || mkiLength == 0 || mkiLength > 128 {
+				return fmt.Errorf("%w: mki `%v`", ErrInvalidCrypto, field)
+			}
+			param.MKI, param.MKILength = value, uint8(mkiLength)
+
+			continue
+		}
+
+		if param.Lifetime != 0 || param.MKILength != 0 {
+			return fmt.Errorf("%w: lifetime `%v`", ErrInvalidCrypto, field)
+		}
+		if param.Lifetime, err = parseCryptoLifetime(field); err != nil {
+			return err
+		}
+	}
+
+	*p = param
+
+	return nil
+}
+
+// parseCryptoLifetime parses a lifetime, a decimal number or a power of two
+// like "2^31".
+func parseCryptoLifetime(value string) (uint64, error) {
+	if exponent, ok := strings.CutPrefix(value, "2^"); ok {
+		n, err := strconv.ParseUint(exponent, 10, 8)
+		if err != nil || n == 0 || n > 63 {
+			return 0, fmt.Errorf("%w: lifetime `%v`", ErrInvalidCrypto, value)
+		}
+
+		return 1 << n, nil
+	}
+
+	lifetime, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || lifetime == 0 {
+		return 0, fmt.Errorf("%w: lifetime `%v`", ErrInvalidCrypto, value)
+	}
+
+	return lifetime, nil
+}
+
+// value writes a lifetime that is a power of two as "2^n", like it is
+// usually written.
+func (p CryptoKeyParam) value() string {
+	value := "inline:" + base64.StdEncoding.EncodeToString(p.Key)
+	switch {
+	case p.Lifetime == 0:
+	case bits.OnesCount64(p.Lifetime) == 1 && p.Lifetime > 1:
+		value += "|2^" + strconv.Itoa(bits.TrailingZeros64(p.Lifetime))
+	default:
+		value += "|" + strconv.FormatUint(p.Lifetime, 10)
+	}
+	if p.MKILength != 0 {
+		value += "|" + strconv.FormatUint(p.MKI, 10) + ":" + strconv.Itoa(int(p.MKILength))
+	}
+
+	return value
+}
+
+// CryptoSessionParams represents the SRTP session parameters of a crypto
+// attribute.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-6.3
+type CryptoSessionParams struct {
+	// KDR is the key derivation rate as a power of two, nil if unspecified.
+	KDR *uint8
+	// WSH is the SRTP window size hint, nil if unspecified.
+	WSH *uint32
+
+	UnencryptedSRTP     bool
+	UnencryptedSRTCP    bool
+	UnauthenticatedSRTP bool
+
+	// Extensions holds FEC_ORDER, FEC_KEY and unknown session parameters
+	// in order.
+	Extensions []string
+}
+
+func (p *CryptoSessionParams) unmarshal(fields []string) error {
+	var params CryptoSessionParams
+	for _, field := range fields {
+		name, value, _ := strings.Cut(field, "=")
+		switch name {
+		case "KDR":
+			kdr, err := strconv.ParseUint(value, 10, 8)
+			if err != nil || kdr > 24 {
+				return fmt.Errorf("%w: session parameter `%v`", ErrInvalidCrypto, field)
+			}
+			params.KDR = new(uint8)
+			*params.KDR = uint8(kdr)
+		case "WSH":
+			wsh, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || wsh < 64 {
+				return fmt.Errorf("%w: session parameter `%v`", ErrInvalidCrypto, field)
+			}
+			params.WSH = new(uint32)
+			*params.WSH = uint32(wsh)
+		case "UNENCRYPTED_SRTP":
+			params.UnencryptedSRTP = true
+		case "UNENCRYPTED_SRTCP":
+			params.UnencryptedSRTCP = true
+		case "UNAUTHENTICATED_SRTP":
+			params.UnauthenticatedSRTP = true
+		default:
+			params.Extensions = append(params.Extensions, field)
+		}
+	}
+
+	*p = params
+
+	return nil
+}
+
+func (p CryptoSessionParams) fields() []string {
+	var fields []string
+	if p.KDR != nil {
+		fields = append(fields, "KDR="+strconv.Itoa(int(*p.KDR)))
+	}
+	if p.UnencryptedSRTP {
+		fields = append(fields, "UNENCRYPTED_SRTP")
+	}
+	if p.UnencryptedSRTCP {
+		fields = append(fields, "UNENCRYPTED_SRTCP")
+	}
+	if p.UnauthenticatedSRTP {
+		fields = append(fields, "UNAUTHENTICATED_SRTP")
+	}
+	if p.WSH != nil {
+		fields = append(fields, "WSH="+strconv.FormatUint(uint64(*p.WSH), 10))
+	}
+
+	return append(fields, p.Extensions...)
+}
+
+// CryptoAttribute represents a crypto attribute, which carries SRTP keys
+// for SDES keying.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-9.1
+type CryptoAttribute struct {
+	// Tag identifies the attribute in the offer/answer exchange.
+	Tag           uint32
+	Suite         CryptoSuite
+	KeyParams     []CryptoKeyParam
+	SessionParams CryptoSessionParams
+}
+
+// NewCryptoAttribute creates a crypto attribute with a random key of the
+// length the crypto suite requires.
+func NewCryptoAttribute(tag uint32, suite CryptoSuite) (CryptoAttribute, error) {
+	if suite.KeyLen() == 0 {
+		return CryptoAttribute{}, fmt.Errorf("%w `%v`", ErrUnknownCryptoSuite, suite)
+	}
+
+	key := make([]byte, suite.KeyLen()+suite.SaltLen())
+	if _, err := rand.Read(key); err != nil {
+		return CryptoAttribute{}, err
+	}
+
+	return CryptoAttribute{Tag: tag, Suite: suite, KeyParams: []CryptoKeyParam{{Key: key}}}, nil
+}
+
+// Unmarshal parses a crypto attribute. The value may be prefixed with
+// "a=crypto:" or "crypto:". It returns an error wrapping
+// ErrUnknownCryptoSuite for crypto suites that are not supported, and checks
+// the key length of the others.
+func (c *CryptoAttribute) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyCrypto+":")
+	fields := strings.Fields(value)
+	if len(fields) < 3 {
+		return fmt.Errorf("%w `%v`", ErrInvalidCrypto, raw)
+	}
+
+	tag, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil || len(fields[0]) > 9 {
+		return fmt.Errorf("%w: tag `%v`", ErrInvalidCrypto, fields[0])
+	}
+
+	suite, err := ParseCryptoSuite(fields[1])
+	if err != nil {
+		return err
+	}
+
+	attr := CryptoAttribute{Tag: uint32(tag), Suite: suite}
+	for _, field := range strings.Split(fields[2], ";") {
+		var param CryptoKeyParam
+		if err := param.unmarshal(field); err != nil {
+			return err
+		}
+		if len(param.Key) != suite.KeyLen()+suite.SaltLen() {
+			return fmt.Errorf("%w: key length %d for %v", ErrInvalidCrypto, len(param.Key), suite)
+		}
+		attr.KeyParams = append(attr.KeyParams, param)
+	}
+
+	if err := attr.SessionParams.unmarshal(fields[3:]); err != nil {
+		return err
+	}
+
+	*c = attr
+
+	return nil
+}
+
+// Marshal returns the crypto attribute, "crypto:" followed by the value.
+func (c CryptoAttribute) Marshal() string {
+	return AttrKeyCrypto + ":" + c.value()
+}
+
+func (c CryptoAttribute) String() string {
+	return c.Marshal()
+}
+
+func (c CryptoAttribute) value() string {
+	keyParams := make([]string, 0, len(c.KeyParams))
+	for _, param := range c.KeyParams {
+		keyParams = append(keyParams, param.value())
+	}
+
+	fields := []string{strconv.FormatUint(uint64(c.Tag), 10), c.Suite.String(), strings.Join(keyParams, ";")}
+
+	return strings.Join(append(fields, c.SessionParams.fields()...), " ")
+}
+
+// Answer creates the crypto attribute that accepts c in an answer: the same
+// tag, crypto suite and session parameters with a new random key.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-7.1.2
+func (c CryptoAttribute) Answer() (CryptoAttribute, error) {
+	answer, err := NewCryptoAttribute(c.Tag, c.Suite)
+	if err != nil {
+		return CryptoAttribute{}, err
+	}
+	answer.SessionParams = c.SessionParams
+
+	return answer, nil
+}
+
+// SelectCrypto returns the first offered crypto attribute whose crypto
+// suite is in supported. Offers list crypto attributes in order of
+// preference.
+func SelectCrypto(offered []CryptoAttribute, supported []CryptoSuite) (CryptoAttribute, error) {
+	for _, c := range offered {
+		for _, suite := range supported {
+			if c.Suite == suite {
+				return c, nil
+			}
+		}
+	}
+
+	return CryptoAttribute{}, ErrNoMatchingCrypto
+}
+
+// Cryptos parses the crypto attributes of the media description in order.
+// Attributes that cannot be used, with an unknown crypto suite, key method
+// or an otherwise malformed value, are skipped, as an answerer ignores them.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-7.1.2
+func (d *MediaDescription) Cryptos() []CryptoAttribute {
+	var cryptos []CryptoAttribute
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeyCrypto {
+			continue
+		}
+
+		var c CryptoAttribute
+		if err := c.Unmarshal(a.Value); err != nil {
+			continue
+		}
+		cryptos = append(cryptos, c)
+	}
+
+	return cryptos
+}
+
+// WithCrypto adds a crypto attribute to the media description.
+func (d *MediaDescription) WithCrypto(c CryptoAttribute) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyCrypto, c.value())
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCryptoAttribute(t *testing.T) {
+	raw := "a=crypto:1 AES_CM_128_HMAC_SHA1_80 " +
+		"inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^20|1:4;inline:QUJDREVGR0hJSktMTU5PUFFSU1RVVldYWVphYmNk|2^20|2:4 " +
+		"KDR=1 UNENCRYPTED_SRTCP WSH=128 FEC_ORDER=FEC_SRTP"
+
+	var c CryptoAttribute
+	assert.NoError(t, c.Unmarshal(raw))
+	assert.Equal(t, uint32(1), c.Tag)
+	assert.Equal(t, CryptoSuiteAESCM128HMACSHA180, c.Suite)
+	assert.Len(t, c.KeyParams, 2)
+	assert.Len(t, c.KeyParams[0].Key, 30)
+	assert.Equal(t, uint64(1<<20), c.KeyParams[0].Lifetime)
+	assert.Equal(t, uint64(2), c.KeyParams[1].MKI)
+	assert.Equal(t, uint8(4), c.KeyParams[1].MKILength)
+	assert.Equal(t, uint8(1), *c.SessionParams.KDR)
+	assert.Equal(t, uint32(128), *c.SessionParams.WSH)
+	assert.True(t, c.SessionParams.UnencryptedSRTCP)
+	assert.False(t, c.SessionParams.UnencryptedSRTP)
+	assert.Equal(t, []string{"FEC_ORDER=FEC_SRTP"}, c.SessionParams.Extensions)
+	assert.Equal(t, raw[len("a="):], c.Marshal())
+
+	assert.NoError(t, c.Unmarshal("crypto:2 AEAD_AES_256_GCM inline:"+
+		"MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWYwMTIzNDU2Nzg5YWI=|1000"))
+	assert.Equal(t, CryptoSuiteAEADAES256GCM, c.Suite)
+	assert.Len(t, c.KeyParams[0].Key, 44)
+	assert.Equal(t, uint64(1000), c.KeyParams[0].Lifetime)
+	assert.Nil(t, c.SessionParams.KDR)
+
+	for _, raw := range []string{
+		"1 AES_CM_128_HMAC_SHA1_80",
+		"x AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR",
+		"1 AES_CM_128_HMAC_SHA1_80 key:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:!!!",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:QUJD",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^64",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|1:0",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR KDR=25",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR WSH=10",
+	} {
+		assert.ErrorIs(t, c.Unmarshal(raw), ErrInvalidCrypto, raw)
+	}
+	assert.ErrorIs(t, c.Unmarshal("1 NULL_HMAC_SHA1_80 inline:QUJD"), ErrUnknownCryptoSuite)
+}
+
+func TestNewCryptoAttribute(t *testing.T) {
+	for suite := CryptoSuiteAESCM128HMACSHA180; suite <= CryptoSuiteAEADAES256GCM; suite++ {
+		c, err := NewCryptoAttribute(1, suite)
+		assert.NoError(t, err)
+		assert.Len(t, c.KeyParams[0].Key, suite.KeyLen()+suite.SaltLen())
+
+		var parsed CryptoAttribute
+		assert.NoError(t, parsed.Unmarshal(c.Marshal()))
+		assert.Equal(t, c, parsed)
+	}
+
+	_, err := NewCryptoAttribute(1, CryptoSuite(0))
+	assert.ErrorIs(t, err, ErrUnknownCryptoSuite)
+	assert.Equal(t, "Unknown", CryptoSuite(0).String())
+}
+
+func TestSelectCrypto(t *testing.T) {
+	md := &MediaDescription{}
+	md.WithValueAttribute("crypto", "1 NULL_HMAC_SHA1_80 inline:QUJD")
+	for tag, suite := range []CryptoSuite{CryptoSuiteAEADAES128GCM, CryptoSuiteAESCM128HMACSHA180} {
+		c, err := NewCryptoAttribute(uint32(tag+2), suite)
+		assert.NoError(t, err)
+		c.SessionParams.UnencryptedSRTCP = true
+		md.WithCrypto(c)
+	}
+
+	md.WithValueAttribute("crypto", "5 AES_CM_128_HMAC_SHA1_80 srtp:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR")
+	md.WithValueAttribute("crypto", "6 AES_CM_128_HMAC_SHA1_80")
+
+	offered := md.Cryptos()
+	assert.Len(t, offered, 2)
+
+	selected, err := SelectCrypto(offered, []CryptoSuite{CryptoSuiteAESCM128HMACSHA132, CryptoSuiteAESCM128HMACSHA180})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), selected.Tag)
+
+	answer, err := selected.Answer()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), answer.Tag)
+	assert.Equal(t, CryptoSuiteAESCM128HMACSHA180, answer.Suite)
+	assert.True(t, answer.SessionParams.UnencryptedSRTCP)
+	assert.NotEqual(t, selected.KeyParams[0].Key, answer.KeyParams[0].Key)
+
+	_, err = SelectCrypto(offered, []CryptoSuite{CryptoSuiteAES256CMHMACSHA180})
+	assert.ErrorIs(t, err, ErrNoMatchingCrypto)
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Decoder reads session descriptions from an input stream. Consecutive
+// session descriptions are split on the "v=" line each of them starts with.
+type Decoder struct {
+	r        *bufio.Reader
+	opts     UnmarshalOptions
+	body     []byte
+	line     []byte
+	pending  bool
+	warnings []ParseWarning
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// SetOptions sets the options used to parse each session description.
+func (d *Decoder) SetOptions(opts UnmarshalOptions) {
+	d.opts = opts
+}
+
+// Warnings returns the warnings collected by the last call to Decode.
+func (d *Decoder) Warnings() []ParseWarning {
+	return d.warnings
+}
+
+// Decode reads the next session description from the stream and stores it
+// in s, replacing its previous contents. A final line without a line ending
+// is terminated with CRLF. Decode returns io.EOF when the stream holds no
+// further session descriptions.
+//
+// The size and line length limits of the options are enforced while
+// reading. A session description that exceeds them is skipped and reported
+// as ErrLimitExceeded, the next call to Decode continues with the one after
+// it.
+func (d *Decoder) Decode(s *SessionDescription) error { //nolint:cyclop
+	d.body = d.body[:0]
+	d.warnings = nil
+	started := false
+	limits := d.opts.Limits.withDefaults()
+	var limitErr error
+
+	if d.pending {
+		d.body = append(d.body, d.line...)
+		d.pending = false
+		started = true
+	}
+
+	for {
+		var err error
+		d.line, err = d.readLine(d.line[:0], limits.MaxLineLength)
+		if errors.Is(err, ErrLimitExceeded) {
+			limitErr, err = err, nil
+			started = true
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		switch {
+		case len(d.line) == 0:
+		case bytes.HasPrefix(d.line, []byte("v=")) && started:
+			d.pending = true
+		case !started && len(bytes.TrimSpace(d.line)) == 0:
+			// Blank lines between session descriptions.
+		case limitErr != nil:
+			// Discard the rest of the session description.
+		default:
+			started = started || bytes.HasPrefix(d.line, []byte("v="))
+			d.body = append(d.body, d.line...)
+			limitErr = checkLimit(len(d.body), limits.MaxSize, "bytes")
+		}
+
+		if d.pending || err != nil {
+			break
+		}
+	}
+
+	if limitErr != nil {
+		return limitErr
+	}
+
+	if len(d.body) == 0 {
+		return io.EOF
+	}
+
+	if d.body[len(d.body)-1] != '\n' {
+		d.body = append(d.body, "\r\n"...)
+	}
+
+	*s = SessionDescription{}
+	var err error
+	d.warnings, err = d.opts.Unmarshal(d.body, s)
+
+	return err
+}
+
+// readLine appends the next line, including its line ending, to b. A line
+// longer than maxLength is consumed but not appended, and reported as
+// ErrLimitExceeded.
+func (d *Decoder) readLine(b []byte, maxLength int) ([]byte, error) {
+	var limitErr error
+	for {
+		chunk, err := d.r.ReadSlice('\n')
+		if limitErr == nil {
+			b = append(b, chunk...)
+			limitErr = checkLimit(len(bytes.TrimRight(b, "\r\n")), maxLength, "bytes in a line")
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			if limitErr != nil {
+				return b[:0], limitErr
+			}
+
+			return b, err
+		}
+	}
+}
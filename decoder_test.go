@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	stream := "\r\n" +
+		CanonicalUnmarshalSDP +
+		SessionInformationSDPLFOnly +
+		"\r\n\r\n" +
+		strings.TrimSuffix(MediaNameSDP, "\r\n")
+
+	decoder := NewDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+
+	var actual []string
+	for {
+		var sd SessionDescription
+		err := decoder.Decode(&sd)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		out, err := sd.Marshal()
+		assert.NoError(t, err)
+		actual = append(actual, string(out))
+	}
+
+	assert.Equal(t, []string{CanonicalUnmarshalSDP, SessionInformationSDP, MediaNameSDP}, actual)
+}
+
+func TestDecoderReplacesContents(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader(MediaNameSDP + MediaNameSDP))
+
+	var sd SessionDescription
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.Len(t, sd.MediaDescriptions, 3)
+	assert.ErrorIs(t, decoder.Decode(&sd), io.EOF)
+}
+
+func TestDecoderOptions(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader(TimingSDP + "x=foo\r\n" + TimingSDP))
+
+	var sd SessionDescription
+	var parseErr *ParseError
+	assert.ErrorAs(t, decoder.Decode(&sd), &parseErr)
+
+	decoder = NewDecoder(strings.NewReader(TimingSDP + "x=foo\r\n" + TimingSDP))
+	decoder.SetOptions(UnmarshalOptions{Lenient: true})
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.Equal(t, []ParseWarning{
+		{Kind: WarningUnknownLineType, Line: 5, Type: 'x', Text: "x=foo"},
+	}, decoder.Warnings())
+
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.Empty(t, decoder.Warnings())
+}
+
+var errRead = errors.New("read failed")
+
+func TestDecoderReadError(t *testing.T) {
+	decoder := NewDecoder(iotest.ErrReader(errRead))
+
+	var sd SessionDescription
+	assert.ErrorIs(t, decoder.Decode(&sd), errRead)
+}
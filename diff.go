@@ -0,0 +1,517 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind identifies how a part of a session description changed.
+type ChangeKind int
+
+const (
+	// ChangeAdded is reported for a part that is only in the new description.
+	ChangeAdded ChangeKind = iota + 1
+
+	// ChangeRemoved is reported for a part that is only in the old
+	// description.
+	ChangeRemoved
+
+	// ChangeModified is reported for a part whose value changed.
+	ChangeModified
+
+	// ChangeMoved is reported for a media description that changed its
+	// position relative to the other media descriptions, but nothing else.
+	ChangeMoved
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeMoved:
+		return "moved"
+	default:
+		return "Unknown"
+	}
+}
+
+// Change describes a single difference between two session or media
+// descriptions.
+type Change struct {
+	Kind ChangeKind
+
+	// Field names what changed. It is one of "version", "origin",
+	// "sessionVersion" (an origin that only differs in its session
+	// version), "sessionName", "information", "uri", "email", "phone",
+	// "connection", "bandwidth", "timing", "timeZones", "encryptionKey",
+	// "media" (the "m=" line apart from its formats), "codecs", "direction"
+	// or "attribute".
+	Field string
+
+	// Key is the attribute key for the "attribute" field.
+	Key string
+
+	// Old and New are the lines before and after the change, empty for
+	// added and removed parts respectively. For "codecs" they list the
+	// formats in order with their rtpmap and fmtp values.
+	Old string
+	New string
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	if c.Old != "" {
+		fmt.Fprintf(&b, "-%s\n", c.line(c.Old))
+	}
+	if c.New != "" {
+		fmt.Fprintf(&b, "+%s\n", c.line(c.New))
+	}
+
+	return b.String()
+}
+
+func (c Change) line(value string) string {
+	if c.Field == "codecs" {
+		return "codecs: " + value
+	}
+
+	return value
+}
+
+// MediaDiff describes a media description that was added, removed, moved
+// or modified.
+type MediaDiff struct {
+	Kind ChangeKind
+
+	// Mid is the "a=mid" the media descriptions were matched by, empty if
+	// they were matched by index.
+	Mid string
+
+	// OldIndex and NewIndex are the positions in the old and new session
+	// description, -1 for added and removed media descriptions.
+	OldIndex int
+	NewIndex int
+
+	// Moved reports whether the media description changed its position
+	// relative to the other media descriptions. The kind is ChangeMoved if
+	// nothing else changed.
+	Moved bool
+
+	// Changes lists the modified lines. It is empty for added, removed and
+	// moved media descriptions.
+	Changes []Change
+
+	lines []string
+}
+
+// SessionDiff is the difference between two session descriptions.
+type SessionDiff struct {
+	// Session lists the changes of session level lines.
+	Session []Change
+
+	// Media lists the media descriptions that changed, in the order of the
+	// new session description followed by the removed ones.
+	Media []MediaDiff
+}
+
+// Empty reports whether the session descriptions are equivalent.
+func (d *SessionDiff) Empty() bool {
+	return len(d.Session) == 0 && len(d.Media) == 0
+}
+
+// String renders the difference in a unified diff like format.
+func (d *SessionDiff) String() string {
+	if d.Empty() {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("--- a\n+++ b\n")
+
+	if len(d.Session) > 0 {
+		b.WriteString("@@ session @@\n")
+		for _, c := range d.Session {
+			b.WriteString(c.String())
+		}
+	}
+
+	for _, m := range d.Media {
+		name := fmt.Sprintf("media %d", m.NewIndex)
+		if m.Kind == ChangeRemoved {
+			name = fmt.Sprintf("media %d", m.OldIndex)
+		}
+		if m.Mid != "" {
+			name += " mid=" + m.Mid
+		}
+
+		switch {
+		case m.Kind == ChangeAdded || m.Kind == ChangeRemoved:
+			fmt.Fprintf(&b, "@@ %s %s @@\n", name, m.Kind)
+		case m.Moved:
+			fmt.Fprintf(&b, "@@ %s moved from %d @@\n", name, m.OldIndex)
+		default:
+			fmt.Fprintf(&b, "@@ %s @@\n", name)
+		}
+
+		prefix := "+"
+		if m.Kind == ChangeRemoved {
+			prefix = "-"
+		}
+		for _, line := range m.lines {
+			b.WriteString(prefix + line + "\n")
+		}
+		for _, c := range m.Changes {
+			b.WriteString(c.String())
+		}
+	}
+
+	return b.String()
+}
+
+// Diff compares two session descriptions at a semantic level. Media
+// descriptions are matched by their "a=mid", media descriptions without
+// one by index. If a mid is repeated, only its first occurrence is matched
+// by mid and the others by index. Attributes are compared as a multiset per
+// key, so their order does not matter. The codecs and the direction are
+// reported as their own fields and not as attribute changes. The media
+// descriptions must not be nil.
+func Diff(a, b *SessionDescription) *SessionDiff {
+	var d differ
+	d.session(a, b)
+
+	matched := matchMedia(a.MediaDescriptions, b.MediaDescriptions)
+
+	// Ranks among the matched media descriptions tell moves apart from
+	// shifts caused by additions and removals.
+	oldRank := map[int]int{}
+	for i := range a.MediaDescriptions {
+		if _, ok := matched[i]; ok {
+			oldRank[i] = len(oldRank)
+		}
+	}
+
+	newToOld := map[int]int{}
+	for i, j := range matched {
+		newToOld[j] = i
+	}
+
+	var media []MediaDiff
+	newRank := 0
+	for j, md := range b.MediaDescriptions {
+		i, ok := newToOld[j]
+		if !ok {
+			media = append(media, MediaDiff{
+				Kind: ChangeAdded, Mid: midOf(md), OldIndex: -1, NewIndex: j, lines: mediaLines(md),
+			})
+
+			continue
+		}
+
+		var changes differ
+		changes.media(a.MediaDescriptions[i], md)
+		diff := MediaDiff{Kind: ChangeModified, OldIndex: i, NewIndex: j, Changes: changes.changes}
+		if m := midOf(md); m != "" && m == midOf(a.MediaDescriptions[i]) {
+			diff.Mid = m
+		}
+		diff.Moved = oldRank[i] != newRank
+		newRank++
+
+		switch {
+		case len(diff.Changes) > 0:
+			media = append(media, diff)
+		case diff.Moved:
+			diff.Kind = ChangeMoved
+			media = append(media, diff)
+		}
+	}
+
+	for i, md := range a.MediaDescriptions {
+		if _, ok := matched[i]; !ok {
+			media = append(media, MediaDiff{
+				Kind: ChangeRemoved, Mid: midOf(md), OldIndex: i, NewIndex: -1, lines: mediaLines(md),
+			})
+		}
+	}
+
+	return &SessionDiff{Session: d.changes, Media: media}
+}
+
+// matchMedia maps the indexes of old media descriptions to the indexes of
+// the matching new ones. Every new media description is matched at most
+// once.
+func matchMedia(old, current []*MediaDescription) map[int]int {
+	matched := map[int]int{}
+	used := map[int]bool{}
+
+	byMid := map[string]int{}
+	for j, md := range current {
+		if m := midOf(md); m != "" {
+			if _, ok := byMid[m]; !ok {
+				byMid[m] = j
+			}
+		}
+	}
+	for i, md := range old {
+		if j, ok := byMid[midOf(md)]; ok && midOf(md) != "" && !used[j] {
+			matched[i] = j
+			used[j] = true
+		}
+	}
+
+	// Media descriptions without a mid, or repeating one, are matched by
+	// index to one with the same mid.
+	for i, md := range old {
+		if _, ok := matched[i]; ok || i >= len(current) || used[i] {
+			continue
+		}
+		if midOf(md) == midOf(current[i]) {
+			matched[i] = i
+			used[i] = true
+		}
+	}
+
+	return matched
+}
+
+func midOf(md *MediaDescription) string {
+	value, _ := md.Attribute(AttrKeyMID)
+
+	return value
+}
+
+func mediaLines(md *MediaDescription) []string {
+	var marsh marshaller
+	md.marshalInto(&marsh)
+
+	return splitLines(marsh)
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) session(a, b *SessionDescription) {
+	d.line("version", "v="+a.Version.String(), "v="+b.Version.String())
+
+	originField := "origin"
+	origin := a.Origin
+	origin.SessionVersion = b.Origin.SessionVersion
+	if origin == b.Origin {
+		originField = "sessionVersion"
+	}
+	d.line(originField, "o="+a.Origin.String(), "o="+b.Origin.String())
+
+	d.line("sessionName", "s="+a.SessionName.String(), "s="+b.SessionName.String())
+	d.line("information", optionalLine("i=", a.SessionInformation), optionalLine("i=", b.SessionInformation))
+	d.line("uri", optionalLine("u=", a.URI), optionalLine("u=", b.URI))
+	d.line("email", optionalLine("e=", a.EmailAddress), optionalLine("e=", b.EmailAddress))
+	d.line("phone", optionalLine("p=", a.PhoneNumber), optionalLine("p=", b.PhoneNumber))
+	d.line("connection", optionalLine("c=", a.ConnectionInformation), optionalLine("c=", b.ConnectionInformation))
+	d.lines("bandwidth", bandwidthLines(a.Bandwidth), bandwidthLines(b.Bandwidth))
+	d.lines("timing", timingLines(a.TimeDescriptions), timingLines(b.TimeDescriptions))
+	d.lines("timeZones", timeZoneLines(a.TimeZones), timeZoneLines(b.TimeZones))
+	d.line("encryptionKey", optionalLine("k=", a.EncryptionKey), optionalLine("k=", b.EncryptionKey))
+	d.line("direction", directionLine(a.Attributes), directionLine(b.Attributes))
+	d.attributes(a.Attributes, b.Attributes)
+}
+
+func (d *differ) media(a, b *MediaDescription) {
+	nameA, nameB := a.MediaName, b.MediaName
+	nameA.Formats, nameB.Formats = nil, nil
+	d.line("media", "m="+strings.TrimSpace(nameA.String()), "m="+strings.TrimSpace(nameB.String()))
+	d.line("codecs", codecList(a), codecList(b))
+
+	d.line("information", optionalLine("i=", a.MediaTitle), optionalLine("i=", b.MediaTitle))
+	d.line("connection", optionalLine("c=", a.ConnectionInformation), optionalLine("c=", b.ConnectionInformation))
+	d.lines("bandwidth", bandwidthLines(a.Bandwidth), bandwidthLines(b.Bandwidth))
+	d.line("encryptionKey", optionalLine("k=", a.EncryptionKey), optionalLine("k=", b.EncryptionKey))
+	d.line("direction", directionLine(a.Attributes), directionLine(b.Attributes))
+	d.attributes(a.Attributes, b.Attributes)
+}
+
+func (d *differ) line(field, old, current string) {
+	switch {
+	case old == current:
+	case old == "":
+		d.changes = append(d.changes, Change{Kind: ChangeAdded, Field: field, New: current})
+	case current == "":
+		d.changes = append(d.changes, Change{Kind: ChangeRemoved, Field: field, Old: old})
+	default:
+		d.changes = append(d.changes, Change{Kind: ChangeModified, Field: field, Old: old, New: current})
+	}
+}
+
+// lines compares two lists of lines as multisets. A single removed and a
+// single added line are reported as a modification.
+func (d *differ) lines(field string, old, current []string) {
+	d.linesWithKey(field, "", old, current)
+}
+
+func (d *differ) linesWithKey(field, key string, old, current []string) {
+	removed, added := multisetDiff(old, current)
+	if len(removed) == 1 && len(added) == 1 {
+		d.changes = append(d.changes, Change{
+			Kind: ChangeModified, Field: field, Key: key, Old: removed[0], New: added[0],
+		})
+
+		return
+	}
+
+	for _, line := range removed {
+		d.changes = append(d.changes, Change{Kind: ChangeRemoved, Field: field, Key: key, Old: line})
+	}
+	for _, line := range added {
+		d.changes = append(d.changes, Change{Kind: ChangeAdded, Field: field, Key: key, New: line})
+	}
+}
+
+func (d *differ) attributes(a, b []Attribute) {
+	var keys []string
+	old, current := map[string][]string{}, map[string][]string{}
+	group := func(attributes []Attribute, lines map[string][]string) {
+		for _, attr := range attributes {
+			if isCodecAttribute(attr.Key) || isDirectionAttribute(attr.Key) {
+				continue
+			}
+			if _, ok := old[attr.Key]; !ok {
+				if _, ok := current[attr.Key]; !ok {
+					keys = append(keys, attr.Key)
+				}
+			}
+			lines[attr.Key] = append(lines[attr.Key], "a="+attr.String())
+		}
+	}
+	group(a, old)
+	group(b, current)
+
+	for _, key := range keys {
+		d.linesWithKey("attribute", key, old[key], current[key])
+	}
+}
+
+func multisetDiff(old, current []string) (removed, added []string) {
+	unmatched := map[string]int{}
+	for _, line := range old {
+		unmatched[line]++
+	}
+	for _, line := range current {
+		if unmatched[line] > 0 {
+			unmatched[line]--
+		} else {
+			added = append(added, line)
+		}
+	}
+
+	for _, line := range old {
+		if unmatched[line] > 0 {
+			unmatched[line]--
+			removed = append(removed, line)
+		}
+	}
+
+	return removed, added
+}
+
+func optionalLine[T interface {
+	comparable
+	String() string
+}](prefix string, value T) string {
+	var zero T
+	if value == zero {
+		return ""
+	}
+
+	return prefix + value.String()
+}
+
+func bandwidthLines(bandwidths []Bandwidth) []string {
+	lines := make([]string, len(bandwidths))
+	for i, b := range bandwidths {
+		lines[i] = "b=" + b.String()
+	}
+
+	return lines
+}
+
+func timingLines(tds []TimeDescription) []string {
+	var lines []string
+	for _, td := range tds {
+		lines = append(lines, "t="+td.Timing.String())
+		for _, r := range td.RepeatTimes {
+			lines = append(lines, "r="+r.String())
+		}
+	}
+
+	return lines
+}
+
+func timeZoneLines(zones []TimeZone) []string {
+	lines := make([]string, len(zones))
+	for i, z := range zones {
+		lines[i] = "z=" + z.String()
+	}
+
+	return lines
+}
+
+func directionLine(attributes []Attribute) string {
+	for _, a := range attributes {
+		if isDirectionAttribute(a.Key) {
+			return "a=" + a.Key
+		}
+	}
+
+	return ""
+}
+
+func isDirectionAttribute(key string) bool {
+	_, err := NewDirection(key)
+
+	return err == nil
+}
+
+func isCodecAttribute(key string) bool {
+	return key == AttrKeyRTPMap || key == AttrKeyFmtp
+}
+
+// codecList describes the formats of a media description in order, each
+// with its rtpmap and fmtp values.
+func codecList(md *MediaDescription) string {
+	rtpmaps, fmtps := map[string]string{}, map[string]string{}
+	for _, a := range md.Attributes {
+		if !isCodecAttribute(a.Key) {
+			continue
+		}
+		format, value, _ := strings.Cut(a.Value, " ")
+		if a.Key == AttrKeyRTPMap {
+			rtpmaps[format] = value
+		} else {
+			fmtps[format] = value
+		}
+	}
+
+	codecs := make([]string, len(md.MediaName.Formats))
+	for i, format := range md.MediaName.Formats {
+		codecs[i] = strings.Join(nonEmpty(format, rtpmaps[format], fmtps[format]), " ")
+	}
+
+	return strings.Join(codecs, ", ")
+}
+
+func nonEmpty(values ...string) []string {
+	out := values[:0]
+	for _, value := range values {
+		if value != "" {
+			out = append(out, value)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseDiffSDP(t *testing.T, value string) *SessionDescription {
+	t.Helper()
+
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(value))
+
+	return sd
+}
+
+func TestDiffEqual(t *testing.T) {
+	a := parseDiffSDP(t, jsepOfferSDP)
+	b := parseDiffSDP(t, jsepOfferSDP)
+
+	// Attribute order does not matter.
+	b.MediaDescriptions[0].Attributes[0], b.MediaDescriptions[0].Attributes[1] =
+		b.MediaDescriptions[0].Attributes[1], b.MediaDescriptions[0].Attributes[0]
+
+	diff := Diff(a, b)
+	assert.True(t, diff.Empty())
+	assert.Equal(t, "", diff.String())
+}
+
+func TestDiff(t *testing.T) {
+	a := parseDiffSDP(t, jsepOfferSDP)
+	b := parseDiffSDP(t, strings.NewReplacer(
+		"o=- 4596489990601351948 2", "o=- 4596489990601351948 3",
+		"a=msid-semantic: WMS\r\n", "a=ice-options:trickle\r\n",
+		"RTP/SAVPF 96\r\n", "RTP/SAVPF 96 97\r\n",
+		"a=rtpmap:96 VP8/90000\r\n", "a=rtpmap:96 VP8/90000\r\na=rtpmap:97 VP9/90000\r\n",
+	).Replace(jsepOfferSDP))
+
+	// Move the data channel to the front and replace the audio section.
+	b.MediaDescriptions = []*MediaDescription{
+		b.MediaDescriptions[2],
+		b.MediaDescriptions[1],
+		NewJSEPMediaDescription("audio", nil).WithValueAttribute(AttrKeyMID, "3"),
+	}
+
+	diff := Diff(a, b)
+	assert.Equal(t, []Change{
+		{
+			Kind:  ChangeModified,
+			Field: "sessionVersion",
+			Old:   "o=- 4596489990601351948 2 IN IP4 127.0.0.1",
+			New:   "o=- 4596489990601351948 3 IN IP4 127.0.0.1",
+		},
+		{Kind: ChangeRemoved, Field: "attribute", Key: "msid-semantic", Old: "a=msid-semantic: WMS"},
+		{Kind: ChangeAdded, Field: "attribute", Key: "ice-options", New: "a=ice-options:trickle"},
+	}, diff.Session)
+
+	if !assert.Len(t, diff.Media, 4) {
+		return
+	}
+
+	assert.Equal(t, ChangeMoved, diff.Media[0].Kind)
+	assert.Equal(t, "2", diff.Media[0].Mid)
+	assert.Equal(t, []int{2, 0}, []int{diff.Media[0].OldIndex, diff.Media[0].NewIndex})
+
+	assert.Equal(t, ChangeModified, diff.Media[1].Kind)
+	assert.True(t, diff.Media[1].Moved)
+	assert.Equal(t, []Change{
+		{Kind: ChangeModified, Field: "codecs", Old: "96 VP8/90000", New: "96 VP8/90000, 97 VP9/90000"},
+	}, diff.Media[1].Changes)
+
+	assert.Equal(t, ChangeAdded, diff.Media[2].Kind)
+	assert.Equal(t, "3", diff.Media[2].Mid)
+	assert.Equal(t, ChangeRemoved, diff.Media[3].Kind)
+	assert.Equal(t, "0", diff.Media[3].Mid)
+
+	assert.Equal(t, "--- a\n"+
+		"+++ b\n"+
+		"@@ session @@\n"+
+		"-o=- 4596489990601351948 2 IN IP4 127.0.0.1\n"+
+		"+o=- 4596489990601351948 3 IN IP4 127.0.0.1\n"+
+		"-a=msid-semantic: WMS\n"+
+		"+a=ice-options:trickle\n"+
+		"@@ media 0 mid=2 moved from 2 @@\n"+
+		"@@ media 1 mid=1 moved from 1 @@\n"+
+		"-codecs: 96 VP8/90000\n"+
+		"+codecs: 96 VP8/90000, 97 VP9/90000\n"+
+		"@@ media 2 mid=3 added @@\n"+
+		"+m=audio 9 UDP/TLS/RTP/SAVPF \n"+
+		"+c=IN IP4 0.0.0.0\n"+
+		"+a=mid:3\n"+
+		"@@ media 0 mid=0 removed @@\n"+
+		"-m=audio 9 UDP/TLS/RTP/SAVPF 111\n"+
+		"-c=IN IP4 0.0.0.0\n"+
+		"-a=ice-ufrag:ETEn\n"+
+		"-a=ice-pwd:OtSK0WpNtpUjkY4+86js7ZQl\n"+
+		"-a=fingerprint:sha-256 19:E2:1C:3B:4B:9F:81:E6:B8:5C:F4:A5:A8:D8:73:04:"+
+		"BB:05:2F:70:9F:04:A9:0E:05:E9:26:33:E8:70:88:A2\n"+
+		"-a=setup:actpass\n"+
+		"-a=mid:0\n"+
+		"-a=sendrecv\n"+
+		"-a=msid:stream0 track0\n"+
+		"-a=rtcp-mux\n"+
+		"-a=rtpmap:111 opus/48000/2\n",
+		diff.String())
+}
+
+func TestDiffDirectionAndIndexMatching(t *testing.T) {
+	a := parseDiffSDP(t, MediaAttributesSDP+"a=sendrecv\r\n")
+	b := parseDiffSDP(t, MediaAttributesSDP+"a=inactive\r\n")
+	b.MediaDescriptions[2].MediaName.Port.Value = 5030
+	b.MediaDescriptions[2].Attributes = append(b.MediaDescriptions[2].Attributes, NewAttribute("rtcp-fb", "97 nack"))
+
+	diff := Diff(a, b)
+	assert.Empty(t, diff.Session)
+	if assert.Len(t, diff.Media, 1) {
+		assert.Equal(t, "", diff.Media[0].Mid)
+		assert.Equal(t, 2, diff.Media[0].NewIndex)
+		assert.Equal(t, []Change{
+			{Kind: ChangeModified, Field: "media", Old: "m=message 5028 TCP/MSRP", New: "m=message 5030 TCP/MSRP"},
+			{Kind: ChangeModified, Field: "direction", Old: "a=sendrecv", New: "a=inactive"},
+			{Kind: ChangeAdded, Field: "attribute", Key: "rtcp-fb", New: "a=rtcp-fb:97 nack"},
+		}, diff.Media[0].Changes)
+	}
+}
+
+func TestDiffDuplicateMid(t *testing.T) {
+	a := parseDiffSDP(t, jsepOfferSDP)
+	b := parseDiffSDP(t, jsepOfferSDP)
+	for _, sd := range []*SessionDescription{a, b} {
+		sd.MediaDescriptions[1].Attributes = removeAttributes(sd.MediaDescriptions[1].Attributes, func(a Attribute) bool {
+			return a.Key == AttrKeyMID
+		})
+		sd.MediaDescriptions[1].WithValueAttribute(AttrKeyMID, "0")
+	}
+	b.MediaDescriptions[1].MediaName.Port.Value = 9
+
+	for i := 0; i < 20; i++ {
+		diff := Diff(a, b)
+		if assert.Len(t, diff.Media, 1) {
+			assert.Equal(t, "0", diff.Media[0].Mid)
+			assert.Equal(t, 1, diff.Media[0].OldIndex)
+			assert.Equal(t, 1, diff.Media[0].NewIndex)
+		}
+	}
+}
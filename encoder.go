@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"io"
+)
+
+// WriteTo marshals the SessionDescription to w. Only one section (the
+// session level lines or a single media description) is held in memory at
+// a time. It implements io.WriterTo.
+func (s *SessionDescription) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	if s.isLossless() {
+		err := s.writeLossless(func(section []byte) error {
+			n, err := w.Write(section)
+			written += int64(n)
+
+			return err
+		})
+
+		return written, err
+	}
+
+	marsh := make(marshaller, 0, s.sectionMarshalSize())
+
+	s.marshalSessionInto(&marsh)
+	n, err := w.Write(marsh)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	for _, md := range s.MediaDescriptions {
+		marsh = marsh[:0]
+		md.marshalInto(&marsh)
+		n, err = w.Write(marsh)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// sectionMarshalSize returns the size of the largest section once
+// marshaled.
+func (s *SessionDescription) sectionMarshalSize() (size int) {
+	size = s.sessionMarshalSize()
+	for _, md := range s.MediaDescriptions {
+		if mediaSize := md.marshalSize(); mediaSize > size {
+			size = mediaSize
+		}
+	}
+
+	return size
+}
+
+// Encoder writes session descriptions to an output stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the marshaled session description to the stream.
+func (e *Encoder) Encode(s *SessionDescription) error {
+	_, err := s.WriteTo(e.w)
+
+	return err
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct {
+	limit int
+}
+
+var errWriteLimit = errors.New("write limit reached")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+
+		return n, errWriteLimit
+	}
+	w.limit -= len(p)
+
+	return len(p), nil
+}
+
+func TestWriteTo(t *testing.T) {
+	var sd SessionDescription
+	assert.NoError(t, sd.UnmarshalString(CanonicalUnmarshalSDP))
+
+	var buf bytes.Buffer
+	n, err := sd.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(CanonicalUnmarshalSDP)), n)
+	assert.Equal(t, CanonicalUnmarshalSDP, buf.String())
+
+	n, err = sd.WriteTo(&failingWriter{limit: 400})
+	assert.ErrorIs(t, err, errWriteLimit)
+	assert.Equal(t, int64(400), n)
+}
+
+func TestEncoder(t *testing.T) {
+	var first, second SessionDescription
+	assert.NoError(t, first.UnmarshalString(CanonicalUnmarshalSDP))
+	assert.NoError(t, second.UnmarshalString(MediaNameSDP))
+
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	assert.NoError(t, encoder.Encode(&first))
+	assert.NoError(t, encoder.Encode(&second))
+	assert.Equal(t, CanonicalUnmarshalSDP+MediaNameSDP, buf.String())
+
+	var decoded SessionDescription
+	decoder := NewDecoder(&buf)
+	assert.NoError(t, decoder.Decode(&decoded))
+	assert.Equal(t, first, decoded)
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+)
+
+// orderedAttributes lists the attributes whose relative order carries
+// meaning, such as a preference.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-7.5
+var orderedAttributes = map[string]bool{ //nolint:gochecknoglobals
+	AttrKeyCrypto: true,
+}
+
+// Equal reports whether two session descriptions are semantically equal.
+// Attributes and bandwidth lines are compared regardless of their order,
+// apart from attributes whose order is significant like "crypto". Codec
+// names in "a=rtpmap" are compared case-insensitively and "a=fmtp"
+// parameters regardless of their order. Media descriptions, formats and
+// time descriptions are compared in order. The original lines kept by
+// lossless parsing are ignored.
+func (s *SessionDescription) Equal(other *SessionDescription) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+
+	if s.Version != other.Version ||
+		s.Origin != other.Origin ||
+		s.SessionName != other.SessionName ||
+		!pointersEqual(s.SessionInformation, other.SessionInformation) ||
+		!pointersEqual(s.EmailAddress, other.EmailAddress) ||
+		!pointersEqual(s.PhoneNumber, other.PhoneNumber) ||
+		!pointersEqual(s.EncryptionKey, other.EncryptionKey) ||
+		!s.ConnectionInformation.equal(other.ConnectionInformation) ||
+		!unorderedEqual(s.Bandwidth, other.Bandwidth, func(a, b Bandwidth) bool { return a == b }) ||
+		!slicesEqual(s.TimeZones, other.TimeZones, func(a, b TimeZone) bool { return a == b }) ||
+		!slicesEqual(s.TimeDescriptions, other.TimeDescriptions, TimeDescription.equal) ||
+		!attributesEqual(s.Attributes, other.Attributes) ||
+		!slicesEqual(s.MediaDescriptions, other.MediaDescriptions, (*MediaDescription).Equal) {
+		return false
+	}
+
+	if s.URI == nil || other.URI == nil {
+		return s.URI == other.URI
+	}
+
+	return s.URI.String() == other.URI.String()
+}
+
+// Equal reports whether two media descriptions are semantically equal, see
+// SessionDescription.Equal.
+func (d *MediaDescription) Equal(other *MediaDescription) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+
+	return d.MediaName.equal(other.MediaName) &&
+		pointersEqual(d.MediaTitle, other.MediaTitle) &&
+		d.ConnectionInformation.equal(other.ConnectionInformation) &&
+		unorderedEqual(d.Bandwidth, other.Bandwidth, func(a, b Bandwidth) bool { return a == b }) &&
+		pointersEqual(d.EncryptionKey, other.EncryptionKey) &&
+		attributesEqual(d.Attributes, other.Attributes)
+}
+
+func (m MediaName) equal(other MediaName) bool {
+	stringsEqual := func(a, b string) bool { return a == b }
+
+	return m.Media == other.Media &&
+		m.Port.Value == other.Port.Value &&
+		pointersEqual(m.Port.Range, other.Port.Range) &&
+		slicesEqual(m.Protos, other.Protos, stringsEqual) &&
+		slicesEqual(m.Formats, other.Formats, stringsEqual)
+}
+
+func (c *ConnectionInformation) equal(other *ConnectionInformation) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+
+	if c.NetworkType != other.NetworkType || c.AddressType != other.AddressType {
+		return false
+	}
+
+	if c.Address == nil || other.Address == nil {
+		return c.Address == other.Address
+	}
+
+	return c.Address.Address == other.Address.Address &&
+		pointersEqual(c.Address.TTL, other.Address.TTL) &&
+		pointersEqual(c.Address.Range, other.Address.Range)
+}
+
+func (t TimeDescription) equal(other TimeDescription) bool {
+	return t.Timing == other.Timing &&
+		slicesEqual(t.RepeatTimes, other.RepeatTimes, func(a, b RepeatTime) bool {
+			return a.Interval == b.Interval && a.Duration == b.Duration &&
+				slicesEqual(a.Offsets, b.Offsets, func(x, y int64) bool { return x == y })
+		})
+}
+
+// attributesEqual compares attributes as a multiset, except for the
+// attributes in orderedAttributes, which are compared in order.
+func attributesEqual(a, b []Attribute) bool {
+	split := func(attributes []Attribute) (ordered, unordered []Attribute) {
+		for _, attr := range attributes {
+			if orderedAttributes[attr.Key] {
+				ordered = append(ordered, attr)
+			} else {
+				unordered = append(unordered, attr)
+			}
+		}
+
+		return ordered, unordered
+	}
+
+	orderedA, unorderedA := split(a)
+	orderedB, unorderedB := split(b)
+
+	return slicesEqual(orderedA, orderedB, attributeEqual) &&
+		unorderedEqual(unorderedA, unorderedB, attributeEqual)
+}
+
+func attributeEqual(a, b Attribute) bool {
+	if a.Key != b.Key {
+		return false
+	}
+
+	switch a.Key {
+	case AttrKeyRTPMap:
+		// <payload type> <encoding name>/<clock rate>[/<encoding parameters>]
+		formatA, codecA, _ := strings.Cut(a.Value, " ")
+		formatB, codecB, _ := strings.Cut(b.Value, " ")
+		nameA, restA, _ := strings.Cut(codecA, "/")
+		nameB, restB, _ := strings.Cut(codecB, "/")
+
+		return formatA == formatB && strings.EqualFold(nameA, nameB) && restA == restB
+	case AttrKeyFmtp:
+		formatA, paramsA, _ := strings.Cut(a.Value, " ")
+		formatB, paramsB, _ := strings.Cut(b.Value, " ")
+
+		return formatA == formatB && equivalentFmtp(paramsA, paramsB)
+	default:
+		return a.Value == b.Value
+	}
+}
+
+func pointersEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func slicesEqual[T any](a, b []T, equal func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unorderedEqual reports whether b is a permutation of a.
+func unorderedEqual[T any](a, b []T, equal func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	used := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for j, y := range b {
+			if !used[j] && equal(x, y) {
+				used[j] = true
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
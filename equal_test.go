@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionDescriptionEqual(t *testing.T) {
+	parse := func(value string) *SessionDescription {
+		sd := &SessionDescription{}
+		assert.NoError(t, sd.UnmarshalString(value))
+
+		return sd
+	}
+
+	sd := parse(CanonicalUnmarshalSDP)
+	assert.True(t, sd.Equal(sd.Clone()))
+	assert.True(t, sd.Equal(parse(CanonicalUnmarshalSDP)))
+
+	lossless := &SessionDescription{}
+	_, err := UnmarshalOptions{Lossless: true}.UnmarshalString(CanonicalUnmarshalSDP, lossless)
+	assert.NoError(t, err)
+	assert.True(t, sd.Equal(lossless))
+
+	for _, test := range []struct {
+		Name   string
+		Modify func(sd *SessionDescription)
+		Equal  bool
+	}{
+		{
+			Name: "AttributeOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.Attributes[0], sd.Attributes[1] = sd.Attributes[1], sd.Attributes[0]
+			},
+			Equal: true,
+		},
+		{
+			Name: "BandwidthOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.Bandwidth[0], sd.Bandwidth[1] = sd.Bandwidth[1], sd.Bandwidth[0]
+			},
+			Equal: true,
+		},
+		{
+			Name: "CodecNameCase",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[1].Attributes[0].Value = "99 H263-1998/90000"
+			},
+			Equal: true,
+		},
+		{
+			Name: "CodecClockRate",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[1].Attributes[0].Value = "99 h263-1998/8000"
+			},
+		},
+		{
+			Name: "FmtpOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[1].Attributes[1].Value = "99 profile-level-id=42e01f; packetization-mode=1"
+			},
+			Equal: true,
+		},
+		{
+			Name: "FmtpValue",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[1].Attributes[1].Value = "99 packetization-mode=0;profile-level-id=42e01f"
+			},
+		},
+		{
+			Name: "CryptoOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.Attributes[2], sd.Attributes[3] = sd.Attributes[3], sd.Attributes[2]
+			},
+		},
+		{
+			Name: "FormatOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[0].MediaName.Formats = []string{"8", "0"}
+			},
+		},
+		{
+			Name: "MediaOrder",
+			Modify: func(sd *SessionDescription) {
+				sd.MediaDescriptions[0], sd.MediaDescriptions[1] = sd.MediaDescriptions[1], sd.MediaDescriptions[0]
+			},
+		},
+		{
+			Name: "URI",
+			Modify: func(sd *SessionDescription) {
+				sd.URI.Path = "/other"
+			},
+		},
+		{
+			Name: "Address",
+			Modify: func(sd *SessionDescription) {
+				ttl := 1
+				sd.ConnectionInformation.Address.TTL = &ttl
+			},
+		},
+		{
+			Name: "SessionVersion",
+			Modify: func(sd *SessionDescription) {
+				sd.Origin.SessionVersion++
+			},
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			base := parse(CanonicalUnmarshalSDP)
+			base.MediaDescriptions[0].MediaName.Formats = []string{"0", "8"}
+			base.MediaDescriptions[1].Attributes = append(base.MediaDescriptions[1].Attributes,
+				NewAttribute("fmtp", "99 packetization-mode=1;profile-level-id=42e01f"))
+			base.Attributes = append(base.Attributes,
+				NewAttribute("crypto", "1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR"),
+				NewAttribute("crypto", "2 AES_CM_128_HMAC_SHA1_32 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR"))
+
+			other := base.Clone()
+			test.Modify(other)
+
+			assert.Equal(t, test.Equal, base.Equal(other))
+			assert.Equal(t, test.Equal, other.Equal(base))
+		})
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var sd *SessionDescription
+	assert.True(t, sd.Equal(nil))
+	assert.False(t, sd.Equal(&SessionDescription{}))
+	assert.True(t, (&SessionDescription{}).Equal(&SessionDescription{Attributes: []Attribute{}}))
+
+	var md *MediaDescription
+	assert.False(t, (&MediaDescription{}).Equal(md))
+}
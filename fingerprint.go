@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	// Register the hash functions used by fingerprints.
+	_ "crypto/sha1"   //nolint:gosec
+	_ "crypto/sha256" // SHA-224 and SHA-256
+	_ "crypto/sha512" // SHA-384 and SHA-512
+)
+
+var (
+	// ErrInvalidFingerprint indicates a fingerprint attribute that does not
+	// follow the grammar of RFC 8122.
+	ErrInvalidFingerprint = errors.New("sdp: invalid fingerprint")
+
+	// ErrUnknownHashFunction indicates a fingerprint hash function that is
+	// not supported.
+	ErrUnknownHashFunction = errors.New("sdp: unknown fingerprint hash function")
+
+	// ErrNoFingerprint indicates that a description has no fingerprint to
+	// verify a certificate against.
+	ErrNoFingerprint = errors.New("sdp: no fingerprint")
+
+	// ErrFingerprintMismatch indicates a certificate that matches none of
+	// the fingerprints of a description.
+	ErrFingerprintMismatch = errors.New("sdp: certificate does not match fingerprint")
+)
+
+// HashFunction is the hash function of a fingerprint. Hash functions are
+// ordered by strength.
+type HashFunction int
+
+// Hash functions of the "Hash Function Textual Names" registry. The
+// deprecated md2 and md5 are not supported.
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+const (
+	HashFunctionSHA1 HashFunction = iota + 1
+	HashFunctionSHA224
+	HashFunctionSHA256
+	HashFunctionSHA384
+	HashFunctionSHA512
+)
+
+var hashFunctionNames = map[HashFunction]string{ //nolint:gochecknoglobals
+	HashFunctionSHA1:   "sha-1",
+	HashFunctionSHA224: "sha-224",
+	HashFunctionSHA256: "sha-256",
+	HashFunctionSHA384: "sha-384",
+	HashFunctionSHA512: "sha-512",
+}
+
+// ParseHashFunction parses the textual name of a hash function, ignoring
+// case.
+func ParseHashFunction(name string) (HashFunction, error) {
+	for h, n := range hashFunctionNames {
+		if strings.EqualFold(n, name) {
+			return h, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w `%v`", ErrUnknownHashFunction, name)
+}
+
+func (h HashFunction) String() string {
+	if name, ok := hashFunctionNames[h]; ok {
+		return name
+	}
+
+	return "Unknown"
+}
+
+// Hash returns the crypto.Hash that implements the hash function.
+func (h HashFunction) Hash() crypto.Hash {
+	switch h {
+	case HashFunctionSHA1:
+		return crypto.SHA1
+	case HashFunctionSHA224:
+		return crypto.SHA224
+	case HashFunctionSHA256:
+		return crypto.SHA256
+	case HashFunctionSHA384:
+		return crypto.SHA384
+	case HashFunctionSHA512:
+		return crypto.SHA512
+	default:
+		return 0
+	}
+}
+
+// Fingerprint represents a certificate fingerprint attribute.
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+type Fingerprint struct {
+	Hash  HashFunction
+	Value []byte
+}
+
+// NewFingerprint computes the fingerprint of a certificate.
+func NewFingerprint(hash HashFunction, cert *x509.Certificate) (Fingerprint, error) {
+	if hash.Hash() == 0 {
+		return Fingerprint{}, fmt.Errorf("%w `%v`", ErrUnknownHashFunction, hash)
+	}
+
+	h := hash.Hash().New()
+	h.Write(cert.Raw) //nolint:errcheck
+
+	return Fingerprint{Hash: hash, Value: h.Sum(nil)}, nil
+}
+
+// Unmarshal parses a fingerprint attribute. The value may be prefixed with
+// "a=fingerprint:" or "fingerprint:".
+func (f *Fingerprint) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyFingerprint+":")
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return fmt.Errorf("%w `%v`", ErrInvalidFingerprint, raw)
+	}
+
+	hash, err := ParseHashFunction(fields[0])
+	if err != nil {
+		return err
+	}
+
+	// 2UHEX *(":" 2UHEX), lower case is accepted as well.
+	digest := fields[1]
+	if len(digest)%3 != 2 {
+		return fmt.Errorf("%w `%v`", ErrInvalidFingerprint, digest)
+	}
+	fingerprint := make([]byte, 0, (len(digest)+1)/3)
+	for i := 0; i < len(digest); i += 3 {
+		if i+2 < len(digest) && digest[i+2] != ':' {
+			return fmt.Errorf("%w `%v`", ErrInvalidFingerprint, digest)
+		}
+		b, err := hex.DecodeString(digest[i : i+2])
+		if err != nil {
+			return fmt.Errorf("%w `%v`", ErrInvalidFingerprint, digest)
+		}
+		fingerprint = append(fingerprint, b[0])
+	}
+	if len(fingerprint) != hash.Hash().Size() {
+		return fmt.Errorf("%w: %d bytes for %v", ErrInvalidFingerprint, len(fingerprint), hash)
+	}
+
+	f.Hash = hash
+	f.Value = fingerprint
+
+	return nil
+}
+
+// Marshal returns the fingerprint attribute, "fingerprint:" followed by the
+// value.
+func (f Fingerprint) Marshal() string {
+	return AttrKeyFingerprint + ":" + f.value()
+}
+
+func (f Fingerprint) String() string {
+	return f.Marshal()
+}
+
+func (f Fingerprint) value() string {
+	b := append([]byte(f.Hash.String()), ' ')
+	for i, v := range f.Value {
+		if i > 0 {
+			b = append(b, ':')
+		}
+		b = append(b, strings.ToUpper(hex.EncodeToString([]byte{v}))...)
+	}
+
+	return string(b)
+}
+
+// Match reports whether the fingerprint is the fingerprint of cert.
+func (f Fingerprint) Match(cert *x509.Certificate) bool {
+	computed, err := NewFingerprint(f.Hash, cert)
+
+	return err == nil && bytes.Equal(computed.Value, f.Value)
+}
+
+// VerifyFingerprints checks cert against a set of fingerprints. Of the
+// fingerprints only those with the strongest hash function are used and
+// cert has to match one of them.
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+func VerifyFingerprints(fingerprints []Fingerprint, cert *x509.Certificate) error {
+	var strongest HashFunction
+	for _, f := range fingerprints {
+		if f.Hash > strongest {
+			strongest = f.Hash
+		}
+	}
+	if strongest == 0 {
+		return ErrNoFingerprint
+	}
+
+	for _, f := range fingerprints {
+		if f.Hash == strongest && f.Match(cert) {
+			return nil
+		}
+	}
+
+	return ErrFingerprintMismatch
+}
+
+// Fingerprints parses the session level fingerprint attributes.
+func (s *SessionDescription) Fingerprints() ([]Fingerprint, error) {
+	return parseFingerprints(s.Attributes)
+}
+
+// Fingerprints parses the fingerprint attributes of the media description.
+func (d *MediaDescription) Fingerprints() ([]Fingerprint, error) {
+	return parseFingerprints(d.Attributes)
+}
+
+// MediaFingerprints returns the fingerprints that apply to a media
+// description: its own fingerprints if it has any, otherwise the session
+// level ones.
+func (s *SessionDescription) MediaFingerprints(md *MediaDescription) ([]Fingerprint, error) {
+	if md != nil && hasAttribute(md.Attributes, AttrKeyFingerprint) {
+		return md.Fingerprints()
+	}
+
+	return s.Fingerprints()
+}
+
+// VerifyCertificate checks a peer certificate against the fingerprints that
+// apply to a media description, see MediaFingerprints and
+// VerifyFingerprints. A nil md uses the session level fingerprints.
+func (s *SessionDescription) VerifyCertificate(md *MediaDescription, cert *x509.Certificate) error {
+	fingerprints, err := s.MediaFingerprints(md)
+	if err != nil {
+		return err
+	}
+
+	return VerifyFingerprints(fingerprints, cert)
+}
+
+// WithDTLSFingerprint adds a fingerprint to the session description.
+func (s *SessionDescription) WithDTLSFingerprint(fingerprint Fingerprint) *SessionDescription {
+	return s.WithValueAttribute(AttrKeyFingerprint, fingerprint.value())
+}
+
+// WithDTLSFingerprint adds a fingerprint to the media description.
+func (d *MediaDescription) WithDTLSFingerprint(fingerprint Fingerprint) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyFingerprint, fingerprint.value())
+}
+
+func parseFingerprints(attributes []Attribute) ([]Fingerprint, error) {
+	var fingerprints []Fingerprint
+	for _, a := range attributes {
+		if a.Key != AttrKeyFingerprint {
+			continue
+		}
+
+		var fingerprint Fingerprint
+		if err := fingerprint.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	return fingerprints, nil
+}
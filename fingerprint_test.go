@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "WebRTC"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	cert, err := x509.ParseCertificate(der)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return cert
+}
+
+func TestFingerprintUnmarshal(t *testing.T) {
+	const value = "sha-256 19:E2:1C:3B:4B:9F:81:E6:B8:5C:F4:A5:A8:D8:73:04:" +
+		"BB:05:2F:70:9F:04:A9:0E:05:E9:26:33:E8:70:88:A2"
+
+	for _, raw := range []string{value, "fingerprint:" + value, "a=fingerprint:" + value} {
+		var f Fingerprint
+		if assert.NoError(t, f.Unmarshal(raw)) {
+			assert.Equal(t, HashFunctionSHA256, f.Hash)
+			assert.Len(t, f.Value, 32)
+			assert.Equal(t, byte(0x19), f.Value[0])
+			assert.Equal(t, "fingerprint:"+value, f.Marshal())
+		}
+	}
+
+	var f Fingerprint
+	assert.NoError(t, f.Unmarshal("SHA-1 4a:ad:b9:b1:3f:82:18:3b:54:02:12:df:3e:5d:49:6b:19:e5:7c:ab"))
+	assert.Equal(t, HashFunctionSHA1, f.Hash)
+	assert.Equal(t, "sha-1 4A:AD:B9:B1:3F:82:18:3B:54:02:12:DF:3E:5D:49:6B:19:E5:7C:AB", f.value())
+}
+
+func TestFingerprintUnmarshalError(t *testing.T) {
+	for raw, expected := range map[string]error{
+		"":                        ErrInvalidFingerprint,
+		"sha-256":                 ErrInvalidFingerprint,
+		"md5 4A:AD":               ErrUnknownHashFunction,
+		"sha-1 4A:AD":             ErrInvalidFingerprint,
+		"sha-1 4AAD":              ErrInvalidFingerprint,
+		"sha-1 4A:A":              ErrInvalidFingerprint,
+		"sha-1 4A-AD":             ErrInvalidFingerprint,
+		"sha-1 ZZ:AD":             ErrInvalidFingerprint,
+		"sha-256 4A:AD extension": ErrInvalidFingerprint,
+	} {
+		var f Fingerprint
+		assert.ErrorIs(t, f.Unmarshal(raw), expected, raw)
+	}
+}
+
+func TestFingerprintCertificate(t *testing.T) {
+	cert := newTestCertificate(t)
+	other := newTestCertificate(t)
+
+	f, err := NewFingerprint(HashFunctionSHA256, cert)
+	assert.NoError(t, err)
+	sum := sha256.Sum256(cert.Raw)
+	assert.Equal(t, sum[:], f.Value)
+	assert.True(t, f.Match(cert))
+	assert.False(t, f.Match(other))
+
+	_, err = NewFingerprint(HashFunction(0), cert)
+	assert.ErrorIs(t, err, ErrUnknownHashFunction)
+	assert.Equal(t, "Unknown", HashFunction(0).String())
+
+	sha1, err := NewFingerprint(HashFunctionSHA1, other)
+	assert.NoError(t, err)
+	sha512, err := NewFingerprint(HashFunctionSHA512, cert)
+	assert.NoError(t, err)
+
+	// Only the strongest hash function counts.
+	assert.NoError(t, VerifyFingerprints([]Fingerprint{sha1, f}, cert))
+	assert.ErrorIs(t, VerifyFingerprints([]Fingerprint{sha1, f}, other), ErrFingerprintMismatch)
+	assert.NoError(t, VerifyFingerprints([]Fingerprint{f, sha512}, cert))
+	assert.ErrorIs(t, VerifyFingerprints(nil, cert), ErrNoFingerprint)
+}
+
+func TestMediaFingerprints(t *testing.T) {
+	cert := newTestCertificate(t)
+	other := newTestCertificate(t)
+	sessionFingerprint, err := NewFingerprint(HashFunctionSHA256, cert)
+	assert.NoError(t, err)
+	mediaFingerprint, err := NewFingerprint(HashFunctionSHA256, other)
+	assert.NoError(t, err)
+
+	audio := NewJSEPMediaDescription("audio", nil)
+	video := NewJSEPMediaDescription("video", nil).WithDTLSFingerprint(mediaFingerprint)
+	sd := (&SessionDescription{}).
+		WithDTLSFingerprint(sessionFingerprint).
+		WithMedia(audio).
+		WithMedia(video)
+
+	fingerprints, err := sd.MediaFingerprints(audio)
+	assert.NoError(t, err)
+	assert.Equal(t, []Fingerprint{sessionFingerprint}, fingerprints)
+
+	fingerprints, err = sd.MediaFingerprints(video)
+	assert.NoError(t, err)
+	assert.Equal(t, []Fingerprint{mediaFingerprint}, fingerprints)
+
+	assert.NoError(t, sd.VerifyCertificate(audio, cert))
+	assert.NoError(t, sd.VerifyCertificate(nil, cert))
+	assert.ErrorIs(t, sd.VerifyCertificate(video, cert), ErrFingerprintMismatch)
+	assert.NoError(t, sd.VerifyCertificate(video, other))
+
+	video.WithFingerprint("sha-256", "bogus")
+	_, err = sd.MediaFingerprints(video)
+	assert.ErrorIs(t, err, ErrInvalidFingerprint)
+}
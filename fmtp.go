@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFmtpParameter indicates a format specific parameter with a
+// value that is not allowed for the codec.
+var ErrInvalidFmtpParameter = errors.New("sdp: invalid format parameter")
+
+// FmtpParam is a format specific parameter. Parameters that are not of the
+// form key=value, like the events "0-15" of telephone-event or the
+// redundancy "96/96" of RED, have an empty Key and the text in Value.
+type FmtpParam struct {
+	Key   string
+	Value string
+}
+
+func (p FmtpParam) String() string {
+	if p.Key == "" {
+		return p.Value
+	}
+
+	return p.Key + "=" + p.Value
+}
+
+// FmtpParams are the format specific parameters of an "a=fmtp" attribute,
+// in order. Keys are compared case-insensitively.
+type FmtpParams []FmtpParam
+
+// ParseFmtpParams parses semicolon separated format specific parameters.
+// Whitespace around parameters is dropped, as are empty parameters.
+func ParseFmtpParams(value string) FmtpParams {
+	var params FmtpParams
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			params = append(params, FmtpParam{Value: part})
+
+			continue
+		}
+		params = append(params, FmtpParam{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+
+	return params
+}
+
+// String marshals the parameters back to their semicolon separated form.
+func (p FmtpParams) String() string {
+	parts := make([]string, len(p))
+	for i, param := range p {
+		parts[i] = param.String()
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Get returns the value of the first parameter with the given key.
+func (p FmtpParams) Get(key string) (string, bool) {
+	for _, param := range p {
+		if param.Key != "" && strings.EqualFold(param.Key, key) {
+			return param.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set replaces the value of the first parameter with the given key, or
+// appends a new parameter if there is none.
+func (p *FmtpParams) Set(key, value string) {
+	for i, param := range *p {
+		if param.Key != "" && strings.EqualFold(param.Key, key) {
+			(*p)[i].Value = value
+
+			return
+		}
+	}
+
+	*p = append(*p, FmtpParam{Key: key, Value: value})
+}
+
+// Delete removes all parameters with the given key.
+func (p *FmtpParams) Delete(key string) {
+	out := (*p)[:0]
+	for _, param := range *p {
+		if param.Key == "" || !strings.EqualFold(param.Key, key) {
+			out = append(out, param)
+		}
+	}
+	*p = out
+}
+
+// Equivalent reports whether both have the same parameters, regardless of
+// their order.
+func (p FmtpParams) Equivalent(other FmtpParams) bool {
+	if len(p) != len(other) {
+		return false
+	}
+
+	canonical := func(params FmtpParams) []string {
+		parts := make([]string, len(params))
+		for i, param := range params {
+			parts[i] = FmtpParam{Key: strings.ToLower(param.Key), Value: param.Value}.String()
+		}
+		sort.Strings(parts)
+
+		return parts
+	}
+	a, b := canonical(p), canonical(other)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fmtpCompatible reports whether the format parameters of two codecs with
+// the given name can be negotiated. known is false for codecs without
+// specific rules, whose parameters have to be equivalent.
+func fmtpCompatible(name string, wanted, got FmtpParams) (compatible, known bool) {
+	switch strings.ToLower(name) {
+	case "h264":
+		return H264SameProfile(wanted, got), true
+	case "h265":
+		return H265SameProfile(wanted, got), true
+	case "vp9":
+		return VP9SameProfile(wanted, got), true
+	case "av1":
+		return AV1SameProfile(wanted, got), true
+	default:
+		return false, false
+	}
+}
+
+// uintParam returns the value of an unsigned integer parameter, or def if
+// it is absent.
+func (p FmtpParams) uintParam(key string, def, maxValue uint64) (uint64, error) {
+	value, ok := p.Get(key)
+	if !ok {
+		return def, nil
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || n > maxValue {
+		return 0, fmt.Errorf("%w: %s `%v`", ErrInvalidFmtpParameter, key, value)
+	}
+
+	return n, nil
+}
+
+// FmtpParams returns the format specific parameters of a payload type.
+func (d *MediaDescription) FmtpParams(payloadType uint8) (FmtpParams, bool) {
+	if i := d.fmtpIndex(payloadType); i >= 0 {
+		_, params, _ := strings.Cut(strings.TrimPrefix(d.Attributes[i].String(), AttrKeyFmtp+":"), " ")
+
+		return ParseFmtpParams(params), true
+	}
+
+	return nil, false
+}
+
+// SetFmtpParams replaces the "a=fmtp" attribute of a payload type, or adds
+// one if there is none. Empty params remove the attribute.
+func (d *MediaDescription) SetFmtpParams(payloadType uint8, params FmtpParams) {
+	i := d.fmtpIndex(payloadType)
+	if len(params) == 0 {
+		if i >= 0 {
+			d.Attributes = append(d.Attributes[:i], d.Attributes[i+1:]...)
+		}
+
+		return
+	}
+
+	attr := NewAttribute(AttrKeyFmtp, strconv.Itoa(int(payloadType))+" "+params.String())
+	if i >= 0 {
+		d.Attributes[i] = attr
+	} else {
+		d.Attributes = append(d.Attributes, attr)
+	}
+}
+
+func (d *MediaDescription) fmtpIndex(payloadType uint8) int {
+	for i, a := range d.Attributes {
+		attr := a.String()
+		if !strings.HasPrefix(attr, AttrKeyFmtp+":") {
+			continue
+		}
+
+		if codec, err := parseFmtp(attr); err == nil && codec.PayloadType == payloadType {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+// AV1Params are the format specific parameters of AV1, with the defaults of
+// absent parameters applied.
+// https://aomediacodec.github.io/av1-rtp-spec/#721-mapping-of-media-subtype-parameters-to-sdp
+type AV1Params struct {
+	// Profile defaults to 0, the Main profile.
+	Profile uint8
+	// LevelIdx defaults to 5, level 3.1.
+	LevelIdx uint8
+	// Tier defaults to 0, the Main tier.
+	Tier uint8
+}
+
+// ParseAV1Params reads the AV1 parameters of format parameters.
+func ParseAV1Params(params FmtpParams) (AV1Params, error) {
+	profile, err := params.uintParam("profile", 0, 2)
+	if err != nil {
+		return AV1Params{}, err
+	}
+	levelIdx, err := params.uintParam("level-idx", 5, 31)
+	if err != nil {
+		return AV1Params{}, err
+	}
+	tier, err := params.uintParam("tier", 0, 1)
+	if err != nil {
+		return AV1Params{}, err
+	}
+
+	return AV1Params{Profile: uint8(profile), LevelIdx: uint8(levelIdx), Tier: uint8(tier)}, nil
+}
+
+// AV1SameProfile reports whether two sets of AV1 format parameters use the
+// same profile. Levels and tiers may differ, the receiver signals what it
+// can decode.
+func AV1SameProfile(a, b FmtpParams) bool {
+	paramsA, errA := ParseAV1Params(a)
+	paramsB, errB := ParseAV1Params(b)
+
+	return errA == nil && errB == nil && paramsA.Profile == paramsB.Profile
+}
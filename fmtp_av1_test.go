@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAV1SameProfile(t *testing.T) {
+	params, err := ParseAV1Params(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, AV1Params{LevelIdx: 5}, params)
+
+	params, err = ParseAV1Params(ParseFmtpParams("profile=1;level-idx=8;tier=1"))
+	assert.NoError(t, err)
+	assert.Equal(t, AV1Params{Profile: 1, LevelIdx: 8, Tier: 1}, params)
+
+	for _, value := range []string{"profile=3", "level-idx=32", "tier=2"} {
+		_, err = ParseAV1Params(ParseFmtpParams(value))
+		assert.ErrorIs(t, err, ErrInvalidFmtpParameter, value)
+	}
+
+	assert.True(t, AV1SameProfile(nil, ParseFmtpParams("profile=0;level-idx=8")))
+	assert.False(t, AV1SameProfile(nil, ParseFmtpParams("profile=1")))
+
+	assert.True(t, codecsCompatible(
+		Codec{Name: "AV1", ClockRate: 90000},
+		Codec{Name: "AV1", ClockRate: 90000, Fmtp: "level-idx=5;profile=0;tier=0"},
+	))
+	assert.False(t, codecsCompatible(
+		Codec{Name: "AV1", Fmtp: "profile=1"},
+		Codec{Name: "AV1", ClockRate: 90000, Fmtp: "level-idx=5;profile=0;tier=0"},
+	))
+}
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidH264ProfileLevelID indicates a profile-level-id that is not
+	// three hex bytes or names an unknown profile.
+	ErrInvalidH264ProfileLevelID = errors.New("sdp: invalid H264 profile-level-id")
+
+	// ErrIncompatibleH264Profile indicates that an offer and an answer use
+	// different H264 profiles or packetization modes.
+	ErrIncompatibleH264Profile = errors.New("sdp: incompatible H264 profiles")
+)
+
+// H264Profile is a profile of H.264 as signalled by profile-level-id.
+type H264Profile int
+
+// H.264 profiles.
+const (
+	H264ProfileConstrainedBaseline H264Profile = iota + 1
+	H264ProfileBaseline
+	H264ProfileMain
+	H264ProfileConstrainedHigh
+	H264ProfileHigh
+	H264ProfilePredictiveHigh444
+)
+
+func (p H264Profile) String() string {
+	switch p {
+	case H264ProfileConstrainedBaseline:
+		return "Constrained Baseline"
+	case H264ProfileBaseline:
+		return "Baseline"
+	case H264ProfileMain:
+		return "Main"
+	case H264ProfileConstrainedHigh:
+		return "Constrained High"
+	case H264ProfileHigh:
+		return "High"
+	case H264ProfilePredictiveHigh444:
+		return "Predictive High 4:4:4"
+	default:
+		return "Unknown"
+	}
+}
+
+// H264Level is the level_idc of H.264, ten times the level number, so
+// level 3.1 is 31. Level 1b has its own value.
+type H264Level uint8
+
+// H.264 levels.
+const (
+	H264Level1b  H264Level = 9
+	H264Level1   H264Level = 10
+	H264Level1_1 H264Level = 11
+	H264Level1_2 H264Level = 12
+	H264Level1_3 H264Level = 13
+	H264Level2   H264Level = 20
+	H264Level2_1 H264Level = 21
+	H264Level2_2 H264Level = 22
+	H264Level3   H264Level = 30
+	H264Level3_1 H264Level = 31
+	H264Level3_2 H264Level = 32
+	H264Level4   H264Level = 40
+	H264Level4_1 H264Level = 41
+	H264Level4_2 H264Level = 42
+	H264Level5   H264Level = 50
+	H264Level5_1 H264Level = 51
+	H264Level5_2 H264Level = 52
+)
+
+// Less reports whether l is a lower level than other. Level 1b lies
+// between level 1 and level 1.1.
+func (l H264Level) Less(other H264Level) bool {
+	rank := func(level H264Level) int {
+		if level == H264Level1b {
+			return 2*int(H264Level1) + 1
+		}
+
+		return 2 * int(level)
+	}
+
+	return rank(l) < rank(other)
+}
+
+// h264ProfilePattern maps profile_idc and a pattern of the profile-iop
+// byte to a profile. In the pattern 'x' matches any bit.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.1
+type h264ProfilePattern struct {
+	profileIDC byte
+	iopMask    byte
+	iopValue   byte
+	profile    H264Profile
+}
+
+var h264ProfilePatterns = []h264ProfilePattern{ //nolint:gochecknoglobals
+	newH264ProfilePattern(0x42, "x1xx0000", H264ProfileConstrainedBaseline),
+	newH264ProfilePattern(0x4D, "1xxx0000", H264ProfileConstrainedBaseline),
+	newH264ProfilePattern(0x58, "11xx0000", H264ProfileConstrainedBaseline),
+	newH264ProfilePattern(0x42, "x0xx0000", H264ProfileBaseline),
+	newH264ProfilePattern(0x58, "10xx0000", H264ProfileBaseline),
+	newH264ProfilePattern(0x4D, "0x0x0000", H264ProfileMain),
+	newH264ProfilePattern(0x64, "00000000", H264ProfileHigh),
+	newH264ProfilePattern(0x64, "00001100", H264ProfileConstrainedHigh),
+	newH264ProfilePattern(0xF4, "00000000", H264ProfilePredictiveHigh444),
+}
+
+func newH264ProfilePattern(profileIDC byte, pattern string, profile H264Profile) h264ProfilePattern {
+	p := h264ProfilePattern{profileIDC: profileIDC, profile: profile}
+	for i := 0; i < 8; i++ {
+		bit := byte(1) << (7 - i)
+		if pattern[i] != 'x' {
+			p.iopMask |= bit
+		}
+		if pattern[i] == '1' {
+			p.iopValue |= bit
+		}
+	}
+
+	return p
+}
+
+// H264ProfileLevelID is the decoded profile-level-id parameter.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.1
+type H264ProfileLevelID struct {
+	Profile H264Profile
+	Level   H264Level
+}
+
+// h264DefaultProfileLevelID is inferred when profile-level-id is absent.
+var h264DefaultProfileLevelID = H264ProfileLevelID{ //nolint:gochecknoglobals
+	Profile: H264ProfileBaseline,
+	Level:   H264Level1,
+}
+
+// ParseH264ProfileLevelID decodes a profile-level-id, three bytes in hex.
+func ParseH264ProfileLevelID(value string) (H264ProfileLevelID, error) {
+	b, err := hex.DecodeString(value)
+	if err != nil || len(b) != 3 {
+		return H264ProfileLevelID{}, fmt.Errorf("%w `%v`", ErrInvalidH264ProfileLevelID, value)
+	}
+	profileIDC, profileIOP, level := b[0], b[1], H264Level(b[2])
+
+	// constraint_set3_flag marks level 1b for the Baseline and Main profiles.
+	const constraintSet3 = 0x10
+	if level == H264Level1_1 && profileIOP&constraintSet3 != 0 && (profileIDC == 0x42 || profileIDC == 0x4D) {
+		level = H264Level1b
+	}
+
+	for _, p := range h264ProfilePatterns {
+		if p.profileIDC == profileIDC && profileIOP&p.iopMask == p.iopValue {
+			return H264ProfileLevelID{Profile: p.profile, Level: level}, nil
+		}
+	}
+
+	return H264ProfileLevelID{}, fmt.Errorf("%w `%v`", ErrInvalidH264ProfileLevelID, value)
+}
+
+// String encodes the profile-level-id in the form browsers use.
+func (p H264ProfileLevelID) String() string {
+	var prefix string
+	switch p.Profile {
+	case H264ProfileConstrainedBaseline:
+		prefix = "42e0"
+	case H264ProfileBaseline:
+		prefix = "4200"
+	case H264ProfileMain:
+		prefix = "4d00"
+	case H264ProfileConstrainedHigh:
+		prefix = "640c"
+	case H264ProfileHigh:
+		prefix = "6400"
+	case H264ProfilePredictiveHigh444:
+		prefix = "f400"
+	default:
+		return ""
+	}
+
+	if p.Level == H264Level1b {
+		switch p.Profile {
+		case H264ProfileConstrainedBaseline:
+			return "42f00b"
+		case H264ProfileBaseline:
+			return "42100b"
+		case H264ProfileMain:
+			return "4d100b"
+		default:
+		}
+	}
+
+	return fmt.Sprintf("%s%02x", prefix, uint8(p.Level))
+}
+
+// H264ProfileLevelIDFromFmtp returns the profile-level-id of format
+// parameters. Without one, Baseline profile at level 1 is inferred.
+func H264ProfileLevelIDFromFmtp(params FmtpParams) (H264ProfileLevelID, error) {
+	value, ok := params.Get("profile-level-id")
+	if !ok {
+		return h264DefaultProfileLevelID, nil
+	}
+
+	return ParseH264ProfileLevelID(value)
+}
+
+// H264SameProfile reports whether two sets of H.264 format parameters can
+// be negotiated: the profiles and the packetization modes have to match.
+// Levels may differ, and Baseline matches Constrained Baseline, as
+// endpoints offer one for the other.
+func H264SameProfile(a, b FmtpParams) bool {
+	profileA, errA := H264ProfileLevelIDFromFmtp(a)
+	profileB, errB := H264ProfileLevelIDFromFmtp(b)
+
+	return errA == nil && errB == nil &&
+		h264CompatibleProfiles(profileA.Profile, profileB.Profile) &&
+		h264PacketizationMode(a) == h264PacketizationMode(b)
+}
+
+func h264CompatibleProfiles(a, b H264Profile) bool {
+	isBaseline := func(p H264Profile) bool {
+		return p == H264ProfileBaseline || p == H264ProfileConstrainedBaseline
+	}
+
+	return a == b || (isBaseline(a) && isBaseline(b))
+}
+
+// H264AnswerProfileLevelID computes the profile-level-id to put in an
+// answer. The level is the lower of the local and the remote level, unless
+// both sides set level-asymmetry-allowed=1, in which case the local level
+// is used. If one side is Baseline and the other Constrained Baseline, the
+// answer uses Constrained Baseline, which both can decode.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.2.2
+func H264AnswerProfileLevelID(local, remote FmtpParams) (H264ProfileLevelID, error) {
+	if !H264SameProfile(local, remote) {
+		return H264ProfileLevelID{}, ErrIncompatibleH264Profile
+	}
+
+	localID, _ := H264ProfileLevelIDFromFmtp(local)
+	remoteID, _ := H264ProfileLevelIDFromFmtp(remote)
+	if localID.Profile != remoteID.Profile {
+		localID.Profile = H264ProfileConstrainedBaseline
+	}
+
+	if h264LevelAsymmetryAllowed(local) && h264LevelAsymmetryAllowed(remote) {
+		return localID, nil
+	}
+
+	if remoteID.Level.Less(localID.Level) {
+		localID.Level = remoteID.Level
+	}
+
+	return localID, nil
+}
+
+func h264PacketizationMode(params FmtpParams) string {
+	if mode, ok := params.Get("packetization-mode"); ok {
+		return mode
+	}
+
+	return "0"
+}
+
+func h264LevelAsymmetryAllowed(params FmtpParams) bool {
+	value, _ := params.Get("level-asymmetry-allowed")
+
+	return value == "1"
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseH264ProfileLevelID(t *testing.T) {
+	for value, expected := range map[string]H264ProfileLevelID{
+		"42e01f": {H264ProfileConstrainedBaseline, H264Level3_1},
+		"42001f": {H264ProfileBaseline, H264Level3_1},
+		"42C02A": {H264ProfileConstrainedBaseline, H264Level4_2},
+		"4d0032": {H264ProfileMain, H264Level5},
+		"4d8015": {H264ProfileConstrainedBaseline, H264Level2_1},
+		"58c00d": {H264ProfileConstrainedBaseline, H264Level1_3},
+		"640c34": {H264ProfileConstrainedHigh, H264Level5_2},
+		"64001f": {H264ProfileHigh, H264Level3_1},
+		"f4001f": {H264ProfilePredictiveHigh444, H264Level3_1},
+		"42f00b": {H264ProfileConstrainedBaseline, H264Level1b},
+		"42100b": {H264ProfileBaseline, H264Level1b},
+		"640009": {H264ProfileHigh, H264Level1b},
+	} {
+		id, err := ParseH264ProfileLevelID(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, id, value)
+	}
+
+	for _, value := range []string{"", "42e0", "42e01f00", "zze01f", "64011f", "6e001f"} {
+		_, err := ParseH264ProfileLevelID(value)
+		assert.ErrorIs(t, err, ErrInvalidH264ProfileLevelID, value)
+	}
+}
+
+func TestH264ProfileLevelIDString(t *testing.T) {
+	for _, value := range []string{
+		"42e01f", "42001f", "4d0032", "640c34", "64001f", "f4001f", "42f00b", "42100b", "4d100b",
+	} {
+		id, err := ParseH264ProfileLevelID(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, value, id.String())
+	}
+
+	assert.Equal(t, "", H264ProfileLevelID{}.String())
+	assert.True(t, H264Level1.Less(H264Level1b))
+	assert.True(t, H264Level1b.Less(H264Level1_1))
+	assert.False(t, H264Level3_1.Less(H264Level3_1))
+}
+
+func TestH264SameProfile(t *testing.T) {
+	for _, test := range []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"profile-level-id=42e01f", "profile-level-id=42c02a", true},
+		{"profile-level-id=42e01f", "profile-level-id=42001f", true},
+		{"profile-level-id=42001f", "profile-level-id=4d001f", false},
+		{"profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42e034;packetization-mode=1", true},
+		{"profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42e01f", false},
+		{"packetization-mode=0", "profile-level-id=42000a", true},
+		{"profile-level-id=bogus", "profile-level-id=bogus", false},
+	} {
+		actual := H264SameProfile(ParseFmtpParams(test.A), ParseFmtpParams(test.B))
+		assert.Equal(t, test.Expected, actual, test.A+" / "+test.B)
+	}
+}
+
+func TestH264AnswerProfileLevelID(t *testing.T) {
+	for _, test := range []struct {
+		Local, Remote string
+		Expected      string
+	}{
+		{"profile-level-id=42e01f", "profile-level-id=42e015", "42e015"},
+		{"profile-level-id=42e015", "profile-level-id=42e01f", "42e015"},
+		{"profile-level-id=42e01f;level-asymmetry-allowed=1", "profile-level-id=42e015", "42e015"},
+		{
+			"profile-level-id=42e01f;level-asymmetry-allowed=1",
+			"profile-level-id=42e015;level-asymmetry-allowed=1",
+			"42e01f",
+		},
+		{"profile-level-id=42e00b", "profile-level-id=42f00b", "42f00b"},
+		{"profile-level-id=42001f", "profile-level-id=42e015", "42e015"},
+	} {
+		id, err := H264AnswerProfileLevelID(ParseFmtpParams(test.Local), ParseFmtpParams(test.Remote))
+		assert.NoError(t, err)
+		assert.Equal(t, test.Expected, id.String(), test.Local+" / "+test.Remote)
+	}
+
+	_, err := H264AnswerProfileLevelID(
+		ParseFmtpParams("profile-level-id=42e01f"),
+		ParseFmtpParams("profile-level-id=64001f"),
+	)
+	assert.ErrorIs(t, err, ErrIncompatibleH264Profile)
+}
+
+func TestGetPayloadTypeForH264(t *testing.T) {
+	sd := getTestSessionDescription()
+
+	// 97 is Constrained Baseline 3.1 with packetization-mode 0.
+	payloadType, err := sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=42c02a"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(97), payloadType)
+
+	// 126 and 98 are both Constrained Baseline with packetization-mode 1,
+	// the equivalent one is preferred over the first in format order.
+	payloadType, err = sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=42e01e;packetization-mode=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(98), payloadType)
+
+	payloadType, err = sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=42e034;packetization-mode=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(126), payloadType)
+
+	_, err = sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=64001f"})
+	assert.ErrorIs(t, err, errCodecNotFound)
+
+	assert.Equal(t, CodecMatchCompatibleFmtp, ScoreCodec(
+		Codec{Name: "H264", Fmtp: "profile-level-id=42001f"},
+		Codec{Name: "H264", Fmtp: "profile-level-id=42000a"},
+	))
+}
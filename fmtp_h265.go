@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+// H265Params are the format specific parameters of H.265 that matter for
+// negotiation, with the defaults of absent parameters applied.
+// https://datatracker.ietf.org/doc/html/rfc7798#section-7.1
+type H265Params struct {
+	// ProfileSpace defaults to 0.
+	ProfileSpace uint8
+	// ProfileID defaults to 1, the Main profile.
+	ProfileID uint8
+	// TierFlag defaults to 0, the Main tier.
+	TierFlag uint8
+	// LevelID is thirty times the level number and defaults to 93, level 3.1.
+	LevelID uint8
+	// TxMode defaults to "SRST".
+	TxMode string
+
+	// SpropVPS, SpropSPS and SpropPPS carry parameter sets in base64. They
+	// describe the stream and do not affect negotiation.
+	SpropVPS string
+	SpropSPS string
+	SpropPPS string
+}
+
+// ParseH265Params reads the H.265 parameters of format parameters.
+func ParseH265Params(params FmtpParams) (H265Params, error) {
+	profileSpace, err := params.uintParam("profile-space", 0, 3)
+	if err != nil {
+		return H265Params{}, err
+	}
+	profileID, err := params.uintParam("profile-id", 1, 31)
+	if err != nil {
+		return H265Params{}, err
+	}
+	tierFlag, err := params.uintParam("tier-flag", 0, 1)
+	if err != nil {
+		return H265Params{}, err
+	}
+	levelID, err := params.uintParam("level-id", 93, 255)
+	if err != nil {
+		return H265Params{}, err
+	}
+
+	h265 := H265Params{
+		ProfileSpace: uint8(profileSpace),
+		ProfileID:    uint8(profileID),
+		TierFlag:     uint8(tierFlag),
+		LevelID:      uint8(levelID),
+		TxMode:       "SRST",
+	}
+	if txMode, ok := params.Get("tx-mode"); ok {
+		h265.TxMode = txMode
+	}
+	h265.SpropVPS, _ = params.Get("sprop-vps")
+	h265.SpropSPS, _ = params.Get("sprop-sps")
+	h265.SpropPPS, _ = params.Get("sprop-pps")
+
+	return h265, nil
+}
+
+// H265SameProfile reports whether two sets of H.265 format parameters can
+// be negotiated: the profile space, profile, tier and transmission mode
+// have to match. Levels may differ.
+func H265SameProfile(a, b FmtpParams) bool {
+	paramsA, errA := ParseH265Params(a)
+	paramsB, errB := ParseH265Params(b)
+
+	return errA == nil && errB == nil &&
+		paramsA.ProfileSpace == paramsB.ProfileSpace &&
+		paramsA.ProfileID == paramsB.ProfileID &&
+		paramsA.TierFlag == paramsB.TierFlag &&
+		paramsA.TxMode == paramsB.TxMode
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseH265Params(t *testing.T) {
+	params, err := ParseH265Params(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, H265Params{ProfileID: 1, LevelID: 93, TxMode: "SRST"}, params)
+
+	params, err = ParseH265Params(ParseFmtpParams(
+		"profile-id=2;tier-flag=1;level-id=120;sprop-vps=QAEMAf//;sprop-sps=QgEBAWA=;sprop-pps=RAHA8vA8kAA=",
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, H265Params{
+		ProfileID: 2, TierFlag: 1, LevelID: 120, TxMode: "SRST",
+		SpropVPS: "QAEMAf//", SpropSPS: "QgEBAWA=", SpropPPS: "RAHA8vA8kAA=",
+	}, params)
+
+	for _, value := range []string{"profile-space=4", "profile-id=x", "tier-flag=2", "level-id=256"} {
+		_, err = ParseH265Params(ParseFmtpParams(value))
+		assert.ErrorIs(t, err, ErrInvalidFmtpParameter, value)
+	}
+}
+
+func TestH265SameProfile(t *testing.T) {
+	for _, test := range []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"", "profile-id=1;tier-flag=0", true},
+		{"level-id=93", "level-id=120;sprop-vps=QAEMAf//", true},
+		{"profile-id=1", "profile-id=2", false},
+		{"tier-flag=1", "", false},
+		{"tx-mode=MRST", "", false},
+		{"profile-id=x", "profile-id=x", false},
+	} {
+		actual := H265SameProfile(ParseFmtpParams(test.A), ParseFmtpParams(test.B))
+		assert.Equal(t, test.Expected, actual, test.A+" / "+test.B)
+	}
+
+	assert.Equal(t, CodecMatchCompatibleFmtp, ScoreCodec(
+		Codec{Name: "H265", Fmtp: "level-id=93"},
+		Codec{Name: "H265", Fmtp: "profile-id=1;level-id=120"},
+	))
+}
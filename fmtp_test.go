@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFmtpParams(t *testing.T) {
+	for _, test := range []struct {
+		Value    string
+		Expected FmtpParams
+		Marshal  string
+	}{
+		{"", nil, ""},
+		{
+			"minptime=10; useinbandfec=1",
+			FmtpParams{{Key: "minptime", Value: "10"}, {Key: "useinbandfec", Value: "1"}},
+			"minptime=10;useinbandfec=1",
+		},
+		{"0-15", FmtpParams{{Value: "0-15"}}, "0-15"},
+		{"96/96", FmtpParams{{Value: "96/96"}}, "96/96"},
+		{
+			"apt=96;;sprop-parameter-sets=Z0IAH5WoFAFuQA==,aM48gA==",
+			FmtpParams{{Key: "apt", Value: "96"}, {Key: "sprop-parameter-sets", Value: "Z0IAH5WoFAFuQA==,aM48gA=="}},
+			"apt=96;sprop-parameter-sets=Z0IAH5WoFAFuQA==,aM48gA==",
+		},
+	} {
+		params := ParseFmtpParams(test.Value)
+		assert.Equal(t, test.Expected, params, test.Value)
+		assert.Equal(t, test.Marshal, params.String(), test.Value)
+	}
+}
+
+func TestFmtpParamsEdit(t *testing.T) {
+	params := ParseFmtpParams("profile-level-id=42e01f;level-asymmetry-allowed=1")
+
+	value, ok := params.Get("Profile-Level-Id")
+	assert.True(t, ok)
+	assert.Equal(t, "42e01f", value)
+	_, ok = params.Get("packetization-mode")
+	assert.False(t, ok)
+
+	params.Set("profile-level-id", "640c1f")
+	params.Set("packetization-mode", "1")
+	assert.Equal(t, "profile-level-id=640c1f;level-asymmetry-allowed=1;packetization-mode=1", params.String())
+
+	params.Delete("LEVEL-ASYMMETRY-ALLOWED")
+	assert.Equal(t, "profile-level-id=640c1f;packetization-mode=1", params.String())
+
+	assert.True(t, params.Equivalent(ParseFmtpParams("packetization-mode=1; PROFILE-LEVEL-ID=640c1f")))
+	assert.False(t, params.Equivalent(ParseFmtpParams("packetization-mode=1")))
+	assert.False(t, params.Equivalent(ParseFmtpParams("packetization-mode=1;profile-level-id=42e01f")))
+}
+
+func TestMediaDescriptionFmtpParams(t *testing.T) {
+	md := getTestSessionDescription().MediaDescriptions[0]
+	md.WithValueAttribute("rtpmap", "101 telephone-event/8000").
+		WithValueAttribute("fmtp", "101 0-15")
+
+	params, ok := md.FmtpParams(97)
+	assert.True(t, ok)
+	assert.Equal(t, "profile-level-id=42e01f;level-asymmetry-allowed=1", params.String())
+
+	params, ok = md.FmtpParams(101)
+	assert.True(t, ok)
+	assert.Equal(t, FmtpParams{{Value: "0-15"}}, params)
+
+	_, ok = md.FmtpParams(100)
+	assert.False(t, ok)
+
+	count := len(md.Attributes)
+	params, _ = md.FmtpParams(97)
+	params.Set("packetization-mode", "1")
+	md.SetFmtpParams(97, params)
+	assert.Len(t, md.Attributes, count)
+	codec, err := md.GetCodecForPayloadType(97)
+	assert.NoError(t, err)
+	assert.Equal(t, "profile-level-id=42e01f;level-asymmetry-allowed=1;packetization-mode=1", codec.Fmtp)
+
+	md.SetFmtpParams(100, FmtpParams{{Key: "apt", Value: "97"}})
+	assert.Equal(t, Attribute{Key: "fmtp", Value: "100 apt=97"}, md.Attributes[len(md.Attributes)-1])
+
+	md.SetFmtpParams(101, nil)
+	_, ok = md.FmtpParams(101)
+	assert.False(t, ok)
+	assert.Len(t, md.Attributes, count)
+}
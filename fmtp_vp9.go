@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+// VP9Params are the format specific parameters of VP9, with the defaults of
+// absent parameters applied.
+// https://datatracker.ietf.org/doc/html/rfc9628#section-6
+type VP9Params struct {
+	// ProfileID defaults to 0.
+	ProfileID uint8
+}
+
+// ParseVP9Params reads the VP9 parameters of format parameters.
+func ParseVP9Params(params FmtpParams) (VP9Params, error) {
+	profileID, err := params.uintParam("profile-id", 0, 3)
+	if err != nil {
+		return VP9Params{}, err
+	}
+
+	return VP9Params{ProfileID: uint8(profileID)}, nil
+}
+
+// VP9SameProfile reports whether two sets of VP9 format parameters use the
+// same profile.
+func VP9SameProfile(a, b FmtpParams) bool {
+	paramsA, errA := ParseVP9Params(a)
+	paramsB, errB := ParseVP9Params(b)
+
+	return errA == nil && errB == nil && paramsA.ProfileID == paramsB.ProfileID
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVP9SameProfile(t *testing.T) {
+	params, err := ParseVP9Params(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, VP9Params{}, params)
+
+	_, err = ParseVP9Params(ParseFmtpParams("profile-id=4"))
+	assert.ErrorIs(t, err, ErrInvalidFmtpParameter)
+
+	assert.True(t, VP9SameProfile(nil, ParseFmtpParams("profile-id=0")))
+	assert.True(t, VP9SameProfile(ParseFmtpParams("profile-id=2"), ParseFmtpParams("profile-id=2")))
+	assert.False(t, VP9SameProfile(nil, ParseFmtpParams("profile-id=2")))
+
+	// 121 is VP9 without profile-id, which means profile 0.
+	sd := getTestSessionDescription()
+	payloadType, err := sd.GetPayloadTypeForCodec(Codec{Name: "VP9", Fmtp: "profile-id=0;max-fs=12288;max-fr=60"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(121), payloadType)
+
+	_, err = sd.GetPayloadTypeForCodec(Codec{Name: "VP9", Fmtp: "profile-id=2"})
+	assert.ErrorIs(t, err, errCodecNotFound)
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidGroup indicates a group attribute that does not follow the
+// grammar of RFC 5888.
+var ErrInvalidGroup = errors.New("sdp: invalid group")
+
+// Group represents a group attribute, which groups media descriptions by
+// their mid.
+// https://datatracker.ietf.org/doc/html/rfc5888#section-5
+type Group struct {
+	// Semantics is a token like SemanticTokenBundle or
+	// SemanticTokenLipSynchronization.
+	Semantics string
+	Mids      []string
+}
+
+// Unmarshal parses a group attribute. The value may be prefixed with
+// "a=group:" or "group:".
+func (g *Group) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyGroup+":")
+	fields := strings.Fields(value)
+	if len(fields) == 0 || !isToken(fields[0]) {
+		return fmt.Errorf("%w `%v`", ErrInvalidGroup, raw)
+	}
+
+	*g = Group{Semantics: fields[0], Mids: fields[1:]}
+
+	return nil
+}
+
+// Marshal returns the group attribute, "group:" followed by the value.
+func (g Group) Marshal() string {
+	return AttrKeyGroup + ":" + g.value()
+}
+
+func (g Group) String() string {
+	return g.Marshal()
+}
+
+func (g Group) value() string {
+	return strings.Join(append([]string{g.Semantics}, g.Mids...), " ")
+}
+
+// Contains reports whether the group lists mid.
+func (g Group) Contains(mid string) bool {
+	for _, m := range g.Mids {
+		if m == mid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Groups parses the group attributes of the session description.
+func (s *SessionDescription) Groups() ([]Group, error) {
+	var groups []Group
+	for _, a := range s.Attributes {
+		if a.Key != AttrKeyGroup {
+			continue
+		}
+
+		var group Group
+		if err := group.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// AddGroup adds a group attribute to the session description.
+func (s *SessionDescription) AddGroup(group Group) *SessionDescription {
+	return s.WithValueAttribute(AttrKeyGroup, group.value())
+}
+
+// RemoveFromGroup removes mid from every group with the given semantics.
+// Groups left without mids are removed as well.
+func (s *SessionDescription) RemoveFromGroup(semantics, mid string) {
+	attributes := s.Attributes[:0]
+	for _, a := range s.Attributes {
+		var group Group
+		if a.Key == AttrKeyGroup && group.Unmarshal(a.Value) == nil && group.Semantics == semantics {
+			mids := group.Mids[:0]
+			for _, m := range group.Mids {
+				if m != mid {
+					mids = append(mids, m)
+				}
+			}
+			if len(mids) == 0 {
+				continue
+			}
+			group.Mids = mids
+			a.Value = group.value()
+		}
+		attributes = append(attributes, a)
+	}
+	s.Attributes = attributes
+}
+
+// BundleTag returns the media description whose transport the media
+// description with the given mid uses: the BUNDLE-tagged one, the first
+// media description of its BUNDLE group that is neither bundle-only nor
+// rejected. It returns nil if mid is not in a BUNDLE group or the group has
+// no such media description.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.2
+func (s *SessionDescription) BundleTag(mid string) *MediaDescription {
+	groups, _ := s.Groups()
+	for _, group := range groups {
+		if group.Semantics != SemanticTokenBundle || !group.Contains(mid) {
+			continue
+		}
+
+		for _, tag := range group.Mids {
+			if md := s.MediaDescriptionByMid(tag); md != nil && !md.IsBundleOnly() && !md.IsRejected() {
+				return md
+			}
+		}
+	}
+
+	return nil
+}
+
+// MediaDescriptionByMid returns the media description with the given mid,
+// or nil.
+func (s *SessionDescription) MediaDescriptionByMid(mid string) *MediaDescription {
+	for _, md := range s.MediaDescriptions {
+		if value, ok := md.Attribute(AttrKeyMID); ok && value == mid {
+			return md
+		}
+	}
+
+	return nil
+}
+
+// IsBundleOnly reports whether the media description is bundle-only: it
+// has port 0 and "a=bundle-only", so it is only used within a BUNDLE group.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-6
+func (d *MediaDescription) IsBundleOnly() bool {
+	return d.MediaName.Port.Value == 0 && hasAttribute(d.Attributes, AttrKeyBundleOnly)
+}
+
+// IsRejected reports whether the media description is rejected or
+// disabled: it has port 0 and is not bundle-only.
+func (d *MediaDescription) IsRejected() bool {
+	return d.MediaName.Port.Value == 0 && !hasAttribute(d.Attributes, AttrKeyBundleOnly)
+}
+
+// RejectMedia rejects a media description: it sets the port to 0, drops
+// "a=bundle-only" and removes its mid from the BUNDLE groups, so that no
+// BUNDLE group references a rejected media description. If the
+// BUNDLE-tagged media description is rejected, the next one in the group
+// becomes the tag. As the tag must not be bundle-only, it loses
+// "a=bundle-only" and gets the port 9.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.3.3
+func (s *SessionDescription) RejectMedia(md *MediaDescription) {
+	tags := s.bundleTags()
+
+	md.MediaName.Port = RangedPort{Value: 0}
+	md.Attributes = removeAttributes(md.Attributes, func(a Attribute) bool {
+		return a.Key == AttrKeyBundleOnly
+	})
+
+	if mid, ok := md.Attribute(AttrKeyMID); ok {
+		s.RemoveFromGroup(SemanticTokenBundle, mid)
+	}
+	s.promoteBundleTags(tags)
+}
+
+// RepairBundleGroups removes the mids of rejected or missing media
+// descriptions from the BUNDLE groups, moving the tag like RejectMedia.
+func (s *SessionDescription) RepairBundleGroups() {
+	tags := s.bundleTags()
+
+	groups, _ := s.Groups()
+	for _, group := range groups {
+		if group.Semantics != SemanticTokenBundle {
+			continue
+		}
+
+		for _, mid := range group.Mids {
+			if md := s.MediaDescriptionByMid(mid); md == nil || md.IsRejected() {
+				s.RemoveFromGroup(SemanticTokenBundle, mid)
+			}
+		}
+	}
+	s.promoteBundleTags(tags)
+}
+
+// bundleTags returns the first mid of every BUNDLE group, the mid of the
+// BUNDLE-tagged media description in an offer.
+func (s *SessionDescription) bundleTags() []string {
+	var tags []string
+	groups, _ := s.Groups()
+	for _, group := range groups {
+		if group.Semantics == SemanticTokenBundle && len(group.Mids) > 0 {
+			tags = append(tags, group.Mids[0])
+		}
+	}
+
+	return tags
+}
+
+// promoteBundleTags makes the media descriptions that became the first of
+// their BUNDLE group, so are not in previous, usable as the tag: a
+// bundle-only one loses "a=bundle-only" and gets the port 9.
+func (s *SessionDescription) promoteBundleTags(previous []string) {
+	for _, tag := range s.bundleTags() {
+		md := s.MediaDescriptionByMid(tag)
+		if containsString(previous, tag) || md == nil || !md.IsBundleOnly() {
+			continue
+		}
+
+		md.MediaName.Port = RangedPort{Value: 9}
+		md.Attributes = removeAttributes(md.Attributes, func(a Attribute) bool {
+			return a.Key == AttrKeyBundleOnly
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupUnmarshal(t *testing.T) {
+	var group Group
+	assert.NoError(t, group.Unmarshal("a=group:BUNDLE 0 1 2"))
+	assert.Equal(t, Group{Semantics: SemanticTokenBundle, Mids: []string{"0", "1", "2"}}, group)
+	assert.Equal(t, "group:BUNDLE 0 1 2", group.Marshal())
+	assert.True(t, group.Contains("1"))
+	assert.False(t, group.Contains("3"))
+
+	assert.NoError(t, group.Unmarshal("LS"))
+	assert.Equal(t, Group{Semantics: SemanticTokenLipSynchronization, Mids: []string{}}, group)
+
+	assert.ErrorIs(t, group.Unmarshal("group:"), ErrInvalidGroup)
+	assert.ErrorIs(t, group.Unmarshal("group:B(NDLE 0"), ErrInvalidGroup)
+}
+
+func TestSessionDescriptionGroups(t *testing.T) {
+	sd := parseDiffSDP(t, jsepOfferSDP)
+
+	groups, err := sd.Groups()
+	assert.NoError(t, err)
+	assert.Equal(t, []Group{{Semantics: SemanticTokenBundle, Mids: []string{"0", "1", "2"}}}, groups)
+
+	sd.AddGroup(Group{Semantics: SemanticTokenLipSynchronization, Mids: []string{"0", "1"}})
+	sd.RemoveFromGroup(SemanticTokenLipSynchronization, "1")
+	groups, err = sd.Groups()
+	assert.NoError(t, err)
+	assert.Equal(t, Group{Semantics: SemanticTokenLipSynchronization, Mids: []string{"0"}}, groups[1])
+
+	sd.RemoveFromGroup(SemanticTokenLipSynchronization, "0")
+	groups, err = sd.Groups()
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+
+	sd.WithValueAttribute(AttrKeyGroup, "")
+	_, err = sd.Groups()
+	assert.ErrorIs(t, err, ErrInvalidGroup)
+}
+
+func TestBundle(t *testing.T) {
+	sd := parseDiffSDP(t, jsepOfferSDP)
+	audio, video, data := sd.MediaDescriptions[0], sd.MediaDescriptions[1], sd.MediaDescriptions[2]
+
+	assert.False(t, audio.IsBundleOnly())
+	assert.True(t, video.IsBundleOnly())
+	assert.False(t, video.IsRejected())
+	assert.Same(t, audio, sd.BundleTag("1"))
+	assert.Same(t, data, sd.MediaDescriptionByMid("2"))
+	assert.Nil(t, sd.BundleTag("3"))
+
+	// A bundle-only section is never the tag.
+	sd.Attributes[0].Value = "BUNDLE 1 0 2"
+	assert.Same(t, audio, sd.BundleTag("2"))
+	sd.Attributes[0].Value = "BUNDLE 0 1 2"
+
+	// Rejecting the BUNDLE-tagged section moves the tag, which stops being
+	// bundle-only.
+	sd.RejectMedia(audio)
+	assert.True(t, audio.IsRejected())
+	assert.Equal(t, []Attribute{{Key: "group", Value: "BUNDLE 1 2"}}, sd.Attributes[:1])
+	assert.Nil(t, sd.BundleTag("0"))
+	assert.False(t, video.IsBundleOnly())
+	assert.Equal(t, 9, video.MediaName.Port.Value)
+	assert.Same(t, video, sd.BundleTag("2"))
+
+	sd.RejectMedia(video)
+	assert.False(t, hasAttribute(video.Attributes, "bundle-only"))
+	assert.Equal(t, "BUNDLE 2", sd.Attributes[0].Value)
+
+	// Repair a description rejected by hand.
+	sd = parseDiffSDP(t, jsepOfferSDP)
+	sd.MediaDescriptions[2].MediaName.Port.Value = 0
+	sd.MediaDescriptions = sd.MediaDescriptions[1:]
+	sd.RepairBundleGroups()
+	assert.Equal(t, "BUNDLE 1", sd.Attributes[0].Value)
+	assert.False(t, sd.MediaDescriptions[0].IsBundleOnly())
+}
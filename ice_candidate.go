@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidICECandidate indicates a candidate attribute that does not
+// follow the grammar of RFC 8839.
+var ErrInvalidICECandidate = errors.New("sdp: invalid ICE candidate")
+
+// Candidate types.
+// https://datatracker.ietf.org/doc/html/rfc8839#section-5.1
+const (
+	ICECandidateTypeHost  = "host"
+	ICECandidateTypeSrflx = "srflx"
+	ICECandidateTypePrflx = "prflx"
+	ICECandidateTypeRelay = "relay"
+)
+
+// ICECandidateExtension is an extension attribute of a candidate that
+// ICECandidate does not represent with a field.
+type ICECandidateExtension struct {
+	Key   string
+	Value string
+}
+
+// ICECandidate represents an ICE candidate attribute.
+// https://datatracker.ietf.org/doc/html/rfc8839#section-5.1
+type ICECandidate struct {
+	Foundation string
+	Component  uint16
+	Transport  string
+	Priority   uint32
+
+	// Address is an IP address or a fully qualified domain name, such as
+	// an mDNS ".local" name.
+	Address string
+	Port    uint16
+
+	// Type is one of the ICECandidateType constants.
+	Type string
+
+	// RelatedAddress and RelatedPort are set for srflx, prflx and relay
+	// candidates. An empty RelatedAddress omits both.
+	RelatedAddress string
+	RelatedPort    uint16
+
+	// TCPType is "active", "passive" or "so" for TCP candidates.
+	TCPType string
+
+	// Generation, Ufrag, NetworkID and NetworkCost are extensions used by
+	// browsers. Nil pointers and empty strings are omitted.
+	Generation  *uint32
+	Ufrag       string
+	NetworkID   *uint32
+	NetworkCost *uint32
+
+	// Extensions holds all other extension attributes in order.
+	Extensions []ICECandidateExtension
+}
+
+// Unmarshal parses a candidate attribute. The value may be prefixed with
+// "a=candidate:" or "candidate:".
+func (c *ICECandidate) Unmarshal(raw string) error { //nolint:cyclop
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyCandidate+":")
+	fields := strings.Fields(value)
+	if len(fields) < 8 || len(fields)%2 != 0 || fields[6] != "typ" {
+		return fmt.Errorf("%w `%v`", ErrInvalidICECandidate, raw)
+	}
+
+	candidate := ICECandidate{
+		Foundation: fields[0],
+		Transport:  fields[2],
+		Address:    fields[4],
+		Type:       fields[7],
+	}
+	if len(candidate.Foundation) > 32 || !isICEChars(candidate.Foundation) {
+		return fmt.Errorf("%w: foundation `%v`", ErrInvalidICECandidate, candidate.Foundation)
+	}
+
+	component, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil || len(fields[1]) > 3 {
+		return fmt.Errorf("%w: component `%v`", ErrInvalidICECandidate, fields[1])
+	}
+	candidate.Component = uint16(component)
+
+	priority, err := strconv.ParseUint(fields[3], 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w: priority `%v`", ErrInvalidICECandidate, fields[3])
+	}
+	candidate.Priority = uint32(priority)
+
+	if net.ParseIP(candidate.Address) == nil && !isDomainName(candidate.Address) {
+		return fmt.Errorf("%w: address `%v`", ErrInvalidICECandidate, candidate.Address)
+	}
+
+	if candidate.Port, err = parseICEPort(fields[5]); err != nil {
+		return err
+	}
+
+	for i := 8; i < len(fields); i += 2 {
+		key, value := fields[i], fields[i+1]
+		switch key {
+		case "raddr":
+			candidate.RelatedAddress = value
+		case "rport":
+			if candidate.RelatedPort, err = parseICEPort(value); err != nil {
+				return err
+			}
+		case "tcptype":
+			candidate.TCPType = value
+		case "generation":
+			candidate.Generation, err = parseICEUint(key, value)
+		case "ufrag":
+			candidate.Ufrag = value
+		case "network-id":
+			candidate.NetworkID, err = parseICEUint(key, value)
+		case "network-cost":
+			candidate.NetworkCost, err = parseICEUint(key, value)
+		default:
+			candidate.Extensions = append(candidate.Extensions, ICECandidateExtension{Key: key, Value: value})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	*c = candidate
+
+	return nil
+}
+
+// Marshal returns the candidate attribute, "candidate:" followed by the
+// value. Known extension attributes precede the other Extensions.
+func (c ICECandidate) Marshal() string {
+	return AttrKeyCandidate + ":" + c.value()
+}
+
+func (c ICECandidate) String() string {
+	return c.Marshal()
+}
+
+func (c ICECandidate) value() string {
+	b := []byte(c.Foundation)
+	b = append(append(b, ' '), strconv.Itoa(int(c.Component))...)
+	b = append(append(b, ' '), c.Transport...)
+	b = append(append(b, ' '), strconv.FormatUint(uint64(c.Priority), 10)...)
+	b = append(append(b, ' '), c.Address...)
+	b = append(append(b, ' '), strconv.Itoa(int(c.Port))...)
+	b = append(append(b, " typ "...), c.Type...)
+
+	appendExtension := func(key, value string) {
+		b = append(append(append(append(b, ' '), key...), ' '), value...)
+	}
+	appendUint := func(key string, value *uint32) {
+		if value != nil {
+			appendExtension(key, strconv.FormatUint(uint64(*value), 10))
+		}
+	}
+
+	if c.RelatedAddress != "" {
+		appendExtension("raddr", c.RelatedAddress)
+		appendExtension("rport", strconv.Itoa(int(c.RelatedPort)))
+	}
+	if c.TCPType != "" {
+		appendExtension("tcptype", c.TCPType)
+	}
+	appendUint("generation", c.Generation)
+	if c.Ufrag != "" {
+		appendExtension("ufrag", c.Ufrag)
+	}
+	appendUint("network-id", c.NetworkID)
+	appendUint("network-cost", c.NetworkCost)
+	for _, e := range c.Extensions {
+		appendExtension(e.Key, e.Value)
+	}
+
+	return string(b)
+}
+
+// ICECandidates parses the candidate attributes of the media description.
+func (d *MediaDescription) ICECandidates() ([]ICECandidate, error) {
+	var candidates []ICECandidate
+	for _, a := range d.Attributes {
+		if !a.IsICECandidate() {
+			continue
+		}
+
+		var candidate ICECandidate
+		if err := candidate.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// WithICECandidate adds an ICE candidate to the media description.
+func (d *MediaDescription) WithICECandidate(candidate ICECandidate) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyCandidate, candidate.value())
+}
+
+func parseICEPort(value string) (uint16, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: port `%v`", ErrInvalidICECandidate, value)
+	}
+
+	return uint16(port), nil
+}
+
+func parseICEUint(key, value string) (*uint32, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s `%v`", ErrInvalidICECandidate, key, value)
+	}
+	v := uint32(n)
+
+	return &v, nil
+}
+
+// isICEChars reports whether value consists of ice-char, that is ALPHA,
+// DIGIT, "+" and "/".
+func isICEChars(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9', ch == '+', ch == '/':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
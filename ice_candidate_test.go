@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICECandidateUnmarshal(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	for _, test := range []struct {
+		Name     string
+		Raw      string
+		Expected ICECandidate
+		Marshal  string
+	}{
+		{
+			Name: "host",
+			Raw:  "candidate:1 1 UDP 2130706431 10.0.1.1 8998 typ host",
+			Expected: ICECandidate{
+				Foundation: "1", Component: 1, Transport: "UDP", Priority: 2130706431,
+				Address: "10.0.1.1", Port: 8998, Type: ICECandidateTypeHost,
+			},
+		},
+		{
+			Name: "mDNS with browser extensions",
+			Raw: "a=candidate:842163049 1 udp 1677729535 4a5b6c7d-1234-5678-9abc-def012345678.local 61665 typ host " +
+				"generation 0 ufrag EsAw network-id 1 network-cost 10",
+			Expected: ICECandidate{
+				Foundation: "842163049", Component: 1, Transport: "udp", Priority: 1677729535,
+				Address: "4a5b6c7d-1234-5678-9abc-def012345678.local", Port: 61665, Type: ICECandidateTypeHost,
+				Generation: uint32Ptr(0), Ufrag: "EsAw", NetworkID: uint32Ptr(1), NetworkCost: uint32Ptr(10),
+			},
+			Marshal: "candidate:842163049 1 udp 1677729535 4a5b6c7d-1234-5678-9abc-def012345678.local 61665 typ host " +
+				"generation 0 ufrag EsAw network-id 1 network-cost 10",
+		},
+		{
+			Name: "srflx",
+			Raw:  "2 1 UDP 1694498815 192.0.2.3 45664 typ srflx raddr 10.0.1.1 rport 8998",
+			Expected: ICECandidate{
+				Foundation: "2", Component: 1, Transport: "UDP", Priority: 1694498815,
+				Address: "192.0.2.3", Port: 45664, Type: ICECandidateTypeSrflx,
+				RelatedAddress: "10.0.1.1", RelatedPort: 8998,
+			},
+			Marshal: "candidate:2 1 UDP 1694498815 192.0.2.3 45664 typ srflx raddr 10.0.1.1 rport 8998",
+		},
+		{
+			Name: "tcp with unknown extension",
+			Raw:  "candidate:3 1 TCP 1518280447 2001:db8::1 9 typ host tcptype active foo bar",
+			Expected: ICECandidate{
+				Foundation: "3", Component: 1, Transport: "TCP", Priority: 1518280447,
+				Address: "2001:db8::1", Port: 9, Type: ICECandidateTypeHost, TCPType: "active",
+				Extensions: []ICECandidateExtension{{Key: "foo", Value: "bar"}},
+			},
+		},
+	} {
+		var candidate ICECandidate
+		if !assert.NoError(t, candidate.Unmarshal(test.Raw), test.Name) {
+			continue
+		}
+		assert.Equal(t, test.Expected, candidate, test.Name)
+
+		expected := test.Marshal
+		if expected == "" {
+			expected = test.Raw
+		}
+		assert.Equal(t, expected, candidate.Marshal(), test.Name)
+	}
+}
+
+func TestICECandidateUnmarshalError(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 8998",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 8998 type host",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 8998 typ host raddr",
+		"candidate:1* 1 UDP 2130706431 10.0.1.1 8998 typ host",
+		"candidate:1 1000 UDP 2130706431 10.0.1.1 8998 typ host",
+		"candidate:1 1 UDP 4294967296 10.0.1.1 8998 typ host",
+		"candidate:1 1 UDP 2130706431 bad_host 8998 typ host",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 65536 typ host",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 8998 typ srflx raddr 10.0.1.1 rport x",
+		"candidate:1 1 UDP 2130706431 10.0.1.1 8998 typ host generation -1",
+	} {
+		var candidate ICECandidate
+		err := candidate.Unmarshal(raw)
+		assert.True(t, errors.Is(err, ErrInvalidICECandidate), raw)
+	}
+}
+
+func TestMediaDescriptionICECandidates(t *testing.T) {
+	candidate := ICECandidate{
+		Foundation: "1", Component: 1, Transport: "UDP", Priority: 2130706431,
+		Address: "10.0.1.1", Port: 8998, Type: ICECandidateTypeHost,
+	}
+	md := NewJSEPMediaDescription("audio", nil).
+		WithICECandidate(candidate).
+		WithValueAttribute(AttrKeyMID, "0")
+
+	assert.Equal(t, Attribute{
+		Key:   AttrKeyCandidate,
+		Value: "1 1 UDP 2130706431 10.0.1.1 8998 typ host",
+	}, md.Attributes[0])
+
+	candidates, err := md.ICECandidates()
+	assert.NoError(t, err)
+	assert.Equal(t, []ICECandidate{candidate}, candidates)
+
+	md.WithCandidate("bogus")
+	_, err = md.ICECandidates()
+	assert.ErrorIs(t, err, ErrInvalidICECandidate)
+}
@@ -33,6 +33,21 @@ const (
 	AttrKeyExtMap           = "extmap"
 	AttrKeyExtMapAllowMixed = "extmap-allow-mixed"
 	AttrKeyCryptex          = "cryptex"
+	AttrKeyRTPMap           = "rtpmap"
+	AttrKeyFmtp             = "fmtp"
+	AttrKeyRTCPFb           = "rtcp-fb"
+	AttrKeyRTCP             = "rtcp"
+	AttrKeyICEUfrag         = "ice-ufrag"
+	AttrKeyICEPwd           = "ice-pwd"
+	AttrKeyFingerprint      = "fingerprint"
+	AttrKeyTLSID            = "tls-id"
+	AttrKeyBundleOnly       = "bundle-only"
+	AttrKeyRID              = "rid"
+	AttrKeySimulcast        = "simulcast"
+	AttrKeyCrypto           = "crypto"
+	AttrKeySCTPPort         = "sctp-port"
+	AttrKeyMaxMessageSize   = "max-message-size"
+	AttrKeySCTPMap          = "sctpmap"
 )
 
 // Constants for semantic tokens used in JSEP.
@@ -43,6 +58,10 @@ const (
 	// https://datatracker.ietf.org/doc/html/rfc5956#section-4.1
 	SemanticTokenForwardErrorCorrectionFramework = "FEC-FR"
 	SemanticTokenWebRTCMediaStreams              = "WMS"
+	// https://datatracker.ietf.org/doc/html/rfc8843#section-7
+	SemanticTokenBundle = "BUNDLE"
+	// Groups the SSRCs of legacy simulcast layers in "a=ssrc-group".
+	SemanticTokenSimulcast = "SIM"
 )
 
 // Constants for extmap key.
@@ -202,6 +221,9 @@ func (d *MediaDescription) WithCodec(
 }
 
 // WithMediaSource adds media source information to the media description.
+//
+// Deprecated: use WithSSRC instead, which only writes the legacy mslabel
+// and label attributes if they are set.
 func (d *MediaDescription) WithMediaSource(ssrc uint32, cname, streamLabel, label string) *MediaDescription {
 	return d.
 		WithValueAttribute("ssrc", fmt.Sprintf("%d cname:%s", ssrc, cname)). // Deprecated but not phased out?
@@ -211,6 +233,7 @@ func (d *MediaDescription) WithMediaSource(ssrc uint32, cname, streamLabel, labe
 }
 
 // WithCandidate adds an ICE candidate to the media description.
+//
 // Deprecated: use WithICECandidate instead.
 func (d *MediaDescription) WithCandidate(value string) *MediaDescription {
 	return d.WithValueAttribute("candidate", value)
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type jsonSessionDescription struct {
+	Version               Version                `json:"version"`
+	Origin                Origin                 `json:"origin"`
+	SessionName           SessionName            `json:"sessionName"`
+	SessionInformation    *Information           `json:"sessionInformation,omitempty"`
+	URI                   string                 `json:"uri,omitempty"`
+	EmailAddress          *EmailAddress          `json:"emailAddress,omitempty"`
+	PhoneNumber           *PhoneNumber           `json:"phoneNumber,omitempty"`
+	ConnectionInformation *ConnectionInformation `json:"connectionInformation,omitempty"`
+	Bandwidth             []Bandwidth            `json:"bandwidth,omitempty"`
+	TimeDescriptions      []TimeDescription      `json:"timeDescriptions"`
+	TimeZones             []TimeZone             `json:"timeZones,omitempty"`
+	EncryptionKey         *EncryptionKey         `json:"encryptionKey,omitempty"`
+	Attributes            []Attribute            `json:"attributes,omitempty"`
+	MediaDescriptions     []*MediaDescription    `json:"mediaDescriptions,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The JSON schema mirrors the
+// structs with camelCase keys and omits optional fields when unset.
+// Origin.SessionID and Origin.SessionVersion are encoded as decimal strings,
+// since JavaScript numbers cannot hold all 64 bit values, and URI is encoded
+// as a string. For example:
+//
+//	{
+//	  "version": 0,
+//	  "origin": {
+//	    "username": "-",
+//	    "sessionId": "4596489990601351948",
+//	    "sessionVersion": "2",
+//	    "networkType": "IN",
+//	    "addressType": "IP4",
+//	    "unicastAddress": "127.0.0.1"
+//	  },
+//	  "sessionName": "-",
+//	  "timeDescriptions": [{"timing": {"startTime": 0, "stopTime": 0}}],
+//	  "attributes": [{"key": "group", "value": "BUNDLE 0"}],
+//	  "mediaDescriptions": [{
+//	    "mediaName": {
+//	      "media": "audio",
+//	      "port": 9,
+//	      "protos": ["UDP", "TLS", "RTP", "SAVPF"],
+//	      "formats": ["111"]
+//	    },
+//	    "connectionInformation": {
+//	      "networkType": "IN",
+//	      "addressType": "IP4",
+//	      "address": {"address": "0.0.0.0"}
+//	    },
+//	    "attributes": [{"key": "mid", "value": "0"}, {"key": "rtcp-mux"}]
+//	  }]
+//	}
+func (s SessionDescription) MarshalJSON() ([]byte, error) {
+	j := jsonSessionDescription{
+		Version:               s.Version,
+		Origin:                s.Origin,
+		SessionName:           s.SessionName,
+		SessionInformation:    s.SessionInformation,
+		EmailAddress:          s.EmailAddress,
+		PhoneNumber:           s.PhoneNumber,
+		ConnectionInformation: s.ConnectionInformation,
+		Bandwidth:             s.Bandwidth,
+		TimeDescriptions:      s.TimeDescriptions,
+		TimeZones:             s.TimeZones,
+		EncryptionKey:         s.EncryptionKey,
+		Attributes:            s.Attributes,
+		MediaDescriptions:     s.MediaDescriptions,
+	}
+	if s.URI != nil {
+		j.URI = s.URI.String()
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SessionDescription) UnmarshalJSON(data []byte) error {
+	var j jsonSessionDescription
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	*s = SessionDescription{
+		Version:               j.Version,
+		Origin:                j.Origin,
+		SessionName:           j.SessionName,
+		SessionInformation:    j.SessionInformation,
+		EmailAddress:          j.EmailAddress,
+		PhoneNumber:           j.PhoneNumber,
+		ConnectionInformation: j.ConnectionInformation,
+		Bandwidth:             j.Bandwidth,
+		TimeDescriptions:      j.TimeDescriptions,
+		TimeZones:             j.TimeZones,
+		EncryptionKey:         j.EncryptionKey,
+		Attributes:            j.Attributes,
+		MediaDescriptions:     j.MediaDescriptions,
+	}
+	if j.URI != "" {
+		uri, err := url.Parse(j.URI)
+		if err != nil {
+			return fmt.Errorf("%w `%v`", ErrInvalidValue, j.URI)
+		}
+		s.URI = uri
+	}
+
+	return nil
+}
+
+type jsonMediaDescription struct {
+	MediaName             MediaName              `json:"mediaName"`
+	MediaTitle            *Information           `json:"mediaTitle,omitempty"`
+	ConnectionInformation *ConnectionInformation `json:"connectionInformation,omitempty"`
+	Bandwidth             []Bandwidth            `json:"bandwidth,omitempty"`
+	EncryptionKey         *EncryptionKey         `json:"encryptionKey,omitempty"`
+	Attributes            []Attribute            `json:"attributes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d MediaDescription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMediaDescription{
+		MediaName:             d.MediaName,
+		MediaTitle:            d.MediaTitle,
+		ConnectionInformation: d.ConnectionInformation,
+		Bandwidth:             d.Bandwidth,
+		EncryptionKey:         d.EncryptionKey,
+		Attributes:            d.Attributes,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *MediaDescription) UnmarshalJSON(data []byte) error {
+	var j jsonMediaDescription
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	*d = MediaDescription{
+		MediaName:             j.MediaName,
+		MediaTitle:            j.MediaTitle,
+		ConnectionInformation: j.ConnectionInformation,
+		Bandwidth:             j.Bandwidth,
+		EncryptionKey:         j.EncryptionKey,
+		Attributes:            j.Attributes,
+	}
+
+	return nil
+}
+
+type jsonMediaName struct {
+	Media     string   `json:"media"`
+	Port      int      `json:"port"`
+	PortRange *int     `json:"portRange,omitempty"`
+	Protos    []string `json:"protos"`
+	Formats   []string `json:"formats"`
+}
+
+// MarshalJSON implements json.Marshaler. The port range is flattened into
+// "port" and "portRange".
+func (m MediaName) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMediaName{
+		Media:     m.Media,
+		Port:      m.Port.Value,
+		PortRange: m.Port.Range,
+		Protos:    m.Protos,
+		Formats:   m.Formats,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *MediaName) UnmarshalJSON(data []byte) error {
+	var j jsonMediaName
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	*m = MediaName{
+		Media:   j.Media,
+		Port:    RangedPort{Value: j.Port, Range: j.PortRange},
+		Protos:  j.Protos,
+		Formats: j.Formats,
+	}
+
+	return nil
+}
+
+type jsonOrigin struct {
+	Username       string `json:"username"`
+	SessionID      uint64 `json:"sessionId,string"`
+	SessionVersion uint64 `json:"sessionVersion,string"`
+	NetworkType    string `json:"networkType"`
+	AddressType    string `json:"addressType"`
+	UnicastAddress string `json:"unicastAddress"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o Origin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonOrigin(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Origin) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonOrigin)(o))
+}
+
+type jsonConnectionInformation struct {
+	NetworkType string   `json:"networkType"`
+	AddressType string   `json:"addressType"`
+	Address     *Address `json:"address,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c ConnectionInformation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonConnectionInformation(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ConnectionInformation) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonConnectionInformation)(c))
+}
+
+type jsonAddress struct {
+	Address string `json:"address"`
+	TTL     *int   `json:"ttl,omitempty"`
+	Range   *int   `json:"range,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonAddress(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Address) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonAddress)(c))
+}
+
+type jsonBandwidth struct {
+	Experimental bool   `json:"experimental,omitempty"`
+	Type         string `json:"type"`
+	Bandwidth    uint64 `json:"bandwidth"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bandwidth) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonBandwidth(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bandwidth) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonBandwidth)(b))
+}
+
+type jsonTimeDescription struct {
+	Timing      Timing       `json:"timing"`
+	RepeatTimes []RepeatTime `json:"repeatTimes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TimeDescription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTimeDescription(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimeDescription) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonTimeDescription)(t))
+}
+
+type jsonTiming struct {
+	StartTime uint64 `json:"startTime"`
+	StopTime  uint64 `json:"stopTime"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Timing) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTiming(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timing) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonTiming)(t))
+}
+
+type jsonRepeatTime struct {
+	Interval int64   `json:"interval"`
+	Duration int64   `json:"duration"`
+	Offsets  []int64 `json:"offsets"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r RepeatTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonRepeatTime(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RepeatTime) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonRepeatTime)(r))
+}
+
+type jsonTimeZone struct {
+	AdjustmentTime uint64 `json:"adjustmentTime"`
+	Offset         int64  `json:"offset"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (z TimeZone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTimeZone(z))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (z *TimeZone) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonTimeZone)(z))
+}
+
+type jsonAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a Attribute) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonAttribute(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Attribute) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonAttribute)(a))
+}
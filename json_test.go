@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, value := range []string{
+		CanonicalUnmarshalSDP,
+		jsepOfferSDP,
+		RepeatTimesSDPExpected,
+		TimeZonesSDP2,
+	} {
+		sd := &SessionDescription{}
+		assert.NoError(t, sd.UnmarshalString(value))
+
+		data, err := json.Marshal(sd)
+		assert.NoError(t, err)
+
+		decoded := &SessionDescription{}
+		assert.NoError(t, json.Unmarshal(data, decoded))
+		assert.Equal(t, sd, decoded)
+
+		actual, err := decoded.Marshal()
+		assert.NoError(t, err)
+		assert.Equal(t, value, string(actual))
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	ttl := 127
+	sd := SessionDescription{
+		Origin: Origin{
+			Username:       "-",
+			SessionID:      18446744073709551615,
+			SessionVersion: 2,
+			NetworkType:    "IN",
+			AddressType:    "IP4",
+			UnicastAddress: "127.0.0.1",
+		},
+		SessionName:      "-",
+		TimeDescriptions: []TimeDescription{{Timing: Timing{StartTime: 0, StopTime: 0}}},
+		Attributes:       []Attribute{NewAttribute("group", "BUNDLE 0")},
+		MediaDescriptions: []*MediaDescription{
+			{
+				MediaName: MediaName{
+					Media:   "audio",
+					Port:    RangedPort{Value: 9},
+					Protos:  []string{"UDP", "TLS", "RTP", "SAVPF"},
+					Formats: []string{"111"},
+				},
+				ConnectionInformation: &ConnectionInformation{
+					NetworkType: "IN",
+					AddressType: "IP4",
+					Address:     &Address{Address: "224.2.17.12", TTL: &ttl},
+				},
+				Bandwidth:  []Bandwidth{{Experimental: true, Type: "YZ", Bandwidth: 128}},
+				Attributes: []Attribute{NewAttribute("mid", "0"), NewPropertyAttribute("rtcp-mux")},
+			},
+		},
+	}
+
+	data, err := json.Marshal(sd)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 0,
+		"origin": {
+			"username": "-",
+			"sessionId": "18446744073709551615",
+			"sessionVersion": "2",
+			"networkType": "IN",
+			"addressType": "IP4",
+			"unicastAddress": "127.0.0.1"
+		},
+		"sessionName": "-",
+		"timeDescriptions": [{"timing": {"startTime": 0, "stopTime": 0}}],
+		"attributes": [{"key": "group", "value": "BUNDLE 0"}],
+		"mediaDescriptions": [{
+			"mediaName": {
+				"media": "audio",
+				"port": 9,
+				"protos": ["UDP", "TLS", "RTP", "SAVPF"],
+				"formats": ["111"]
+			},
+			"connectionInformation": {
+				"networkType": "IN",
+				"addressType": "IP4",
+				"address": {"address": "224.2.17.12", "ttl": 127}
+			},
+			"bandwidth": [{"experimental": true, "type": "YZ", "bandwidth": 128}],
+			"attributes": [{"key": "mid", "value": "0"}, {"key": "rtcp-mux"}]
+		}]
+	}`, string(data))
+}
+
+func TestJSONUnmarshalErrors(t *testing.T) {
+	var sd SessionDescription
+	assert.Error(t, json.Unmarshal([]byte(`{"origin": {"sessionId": 1}}`), &sd))
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"uri": "http://[::1"}`), &sd), ErrInvalidValue)
+}
@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// errNoSessionDescription is returned in lenient mode for input without a
+// "v=" or "o=" line.
+var errNoSessionDescription = fmt.Errorf("%w: no v= or o= line", ErrInvalidSyntax) //nolint:gochecknoglobals
+
+// WarningKind identifies a recoverable problem found while parsing in
+// lenient mode.
+type WarningKind int
+
+const (
+	// WarningMisplacedLine is reported for a known line type that appears
+	// where the grammar does not allow it. The line is still parsed.
+	WarningMisplacedLine WarningKind = iota + 1
+
+	// WarningUnknownLineType is reported for a line with a type letter that
+	// is not defined by SDP. The line is skipped.
+	WarningUnknownLineType
+
+	// WarningMalformedLine is reported for a line that is not of the form
+	// <type>=<value>, which is skipped, or for a line with fields after its
+	// value, which are dropped.
+	WarningMalformedLine
+
+	// WarningDuplicateLine is reported for a repeated "v=", "o=" or "s="
+	// line. The repeated line is skipped.
+	WarningDuplicateLine
+
+	// WarningMissingTiming is reported when no "t=" line precedes the
+	// session attributes or media descriptions.
+	WarningMissingTiming
+
+	// WarningTrailingWhitespace is reported for a line that ends in spaces
+	// or tabs. The whitespace is dropped.
+	WarningTrailingWhitespace
+
+	// WarningMissingVersion is reported when there is no "v=" line.
+	WarningMissingVersion
+
+	// WarningMissingOrigin is reported when there is no "o=" line.
+	WarningMissingOrigin
+
+	// WarningMissingSessionName is reported when there is no "s=" line.
+	WarningMissingSessionName
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case WarningMisplacedLine:
+		return "misplaced line"
+	case WarningUnknownLineType:
+		return "unknown line type"
+	case WarningMalformedLine:
+		return "malformed line"
+	case WarningDuplicateLine:
+		return "duplicate line"
+	case WarningMissingTiming:
+		return "missing t= line"
+	case WarningTrailingWhitespace:
+		return "trailing whitespace"
+	case WarningMissingVersion:
+		return "missing v= line"
+	case WarningMissingOrigin:
+		return "missing o= line"
+	case WarningMissingSessionName:
+		return "missing s= line"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseWarning describes a recoverable problem that the parser skipped over
+// in lenient mode.
+type ParseWarning struct {
+	Kind WarningKind
+
+	// Line is the 1-based number of the offending line.
+	Line int
+
+	// Type is the line type, for example 'a' for an attribute line. It is
+	// zero for malformed lines.
+	Type byte
+
+	// Text is the offending line without its line ending. It is empty for
+	// the warnings about missing lines.
+	Text string
+}
+
+func (w ParseWarning) String() string {
+	if w.Text == "" {
+		return fmt.Sprintf("sdp: line %d: %s", w.Line, w.Kind)
+	}
+
+	return fmt.Sprintf("sdp: line %d: %s: %s", w.Line, w.Kind, strconv.QuoteToASCII(w.Text))
+}
+
+// linePhase orders the parts of a session description. The lenient parser
+// uses it to decide whether a misplaced line moves parsing forward or is an
+// out of order leftover of an earlier part.
+type linePhase int
+
+const (
+	phaseHeader linePhase = iota
+	phaseSession
+	phaseTiming
+	phaseMedia
+)
+
+// lenientHandler returns the state that parses a line of the given type
+// regardless of the current state, together with the phase the line
+// belongs to.
+func (l *lexer) lenientHandler(key byte) (stateFn, linePhase) { //nolint:cyclop
+	switch key {
+	case 'v':
+		return unmarshalProtocolVersion, phaseHeader
+	case 'o':
+		return unmarshalOrigin, phaseHeader
+	case 's':
+		return unmarshalSessionName, phaseHeader
+	case 'u':
+		return unmarshalURI, phaseSession
+	case 'e':
+		return unmarshalEmail, phaseSession
+	case 'p':
+		return unmarshalPhone, phaseSession
+	case 't':
+		return unmarshalTiming, phaseTiming
+	case 'r':
+		return unmarshalRepeatTimes, phaseTiming
+	case 'z':
+		return unmarshalTimeZones, phaseTiming
+	case 'm':
+		return unmarshalMediaDescription, phaseMedia
+	}
+
+	if len(l.desc.MediaDescriptions) > 0 {
+		switch key {
+		case 'i':
+			return unmarshalMediaTitle, phaseMedia
+		case 'c':
+			return unmarshalMediaConnectionInformation, phaseMedia
+		case 'b':
+			return unmarshalMediaBandwidth, phaseMedia
+		case 'k':
+			return unmarshalMediaEncryptionKey, phaseMedia
+		case 'a':
+			return unmarshalMediaAttribute, phaseMedia
+		}
+
+		return nil, phaseMedia
+	}
+
+	switch key {
+	case 'i':
+		return unmarshalSessionInformation, phaseSession
+	case 'c':
+		return unmarshalSessionConnectionInformation, phaseSession
+	case 'b':
+		return unmarshalSessionBandwidth, phaseSession
+	case 'k':
+		return unmarshalSessionEncryptionKey, phaseTiming
+	case 'a':
+		return unmarshalSessionAttribute, phaseTiming
+	}
+
+	return nil, phaseHeader
+}
+
+// accept records that a line of the given type was parsed.
+func (l *lexer) accept(key byte) {
+	if !l.lenient {
+		return
+	}
+
+	if key >= 'a' && key <= 'z' {
+		l.seen |= 1 << (key - 'a')
+	}
+
+	if _, phase := l.lenientHandler(key); phase > l.phase {
+		l.phase = phase
+	}
+}
+
+func (l *lexer) hasSeen(key byte) bool {
+	return l.seen&(1<<(key-'a')) != 0
+}
+
+// recoverLine decides how the lenient parser continues after a line that
+// the grammar does not allow in the current state.
+func (l *lexer) recoverLine(key byte) stateFn {
+	handler, phase := l.lenientHandler(key)
+
+	switch {
+	case handler == nil:
+		return l.skipLineWithWarning(WarningUnknownLineType, key)
+	case (key == 'v' || key == 'o' || key == 's') && l.hasSeen(key):
+		return l.skipLineWithWarning(WarningDuplicateLine, key)
+	case key == 'r' && !l.hasSeen('t'):
+		return l.skipLineWithWarning(WarningMisplacedLine, key)
+	case phase >= phaseTiming && key != 't' && !l.hasSeen('t'):
+		l.warnMissingTiming()
+	default:
+		l.warn(WarningMisplacedLine, key)
+	}
+
+	if phase < l.phase {
+		// A leftover of an earlier part, parse it and carry on where we were.
+		next := l.state
+		l.accept(key)
+
+		return func(l *lexer) (stateFn, error) {
+			if _, err := handler(l); err != nil {
+				return nil, err
+			}
+
+			return next, nil
+		}
+	}
+
+	l.accept(key)
+
+	return handler
+}
+
+func (l *lexer) skipMalformedLine() stateFn {
+	l.pos = l.lineStart
+
+	return l.skipLineWithWarning(WarningMalformedLine, 0)
+}
+
+func (l *lexer) skipLineWithWarning(kind WarningKind, key byte) stateFn {
+	l.warn(kind, key)
+	l.skipLine()
+	l.recordSkippedLine()
+
+	return l.state
+}
+
+func (l *lexer) checkTrailingWhitespace(key byte) {
+	if line := l.currentLine(); line != "" && isWhitespace(line[len(line)-1]) {
+		l.warn(WarningTrailingWhitespace, key)
+	}
+}
+
+func (l *lexer) warn(kind WarningKind, key byte) {
+	l.warnings = append(l.warnings, ParseWarning{
+		Kind: kind,
+		Line: l.lineNumber(),
+		Type: key,
+		Text: l.currentLine(),
+	})
+}
+
+// checkHeader reports the absent "v=", "o=" and "s=" lines. A description
+// with neither "v=" nor "o=" is not taken for SDP at all.
+func (l *lexer) checkHeader() error {
+	if !l.hasSeen('v') && !l.hasSeen('o') {
+		return errNoSessionDescription
+	}
+
+	for _, missing := range []struct {
+		key  byte
+		kind WarningKind
+	}{
+		{'v', WarningMissingVersion},
+		{'o', WarningMissingOrigin},
+		{'s', WarningMissingSessionName},
+	} {
+		if !l.hasSeen(missing.key) {
+			l.warnings = append(l.warnings, ParseWarning{
+				Kind: missing.kind,
+				Line: l.lineNumber(),
+				Type: missing.key,
+			})
+		}
+	}
+
+	return nil
+}
+
+// warnMissingTiming reports the absent "t=" line once.
+func (l *lexer) warnMissingTiming() {
+	for _, w := range l.warnings {
+		if w.Kind == WarningMissingTiming {
+			return
+		}
+	}
+
+	l.warnings = append(l.warnings, ParseWarning{
+		Kind: WarningMissingTiming,
+		Line: l.lineNumber(),
+		Type: 't',
+	})
+}
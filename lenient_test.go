@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalLenient(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		SDP      string
+		Actual   string
+		Warnings []ParseWarning
+	}{
+		{
+			Name:   "Valid",
+			SDP:    CanonicalUnmarshalSDP,
+			Actual: CanonicalUnmarshalSDP,
+		},
+		{
+			Name: "UnknownLineType",
+			SDP:  TimingSDP + "x=foo\r\n" + "a=recvonly\r\n",
+			Actual: TimingSDP +
+				"a=recvonly\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningUnknownLineType, Line: 5, Type: 'x', Text: "x=foo"},
+			},
+		},
+		{
+			Name:   "MalformedLine",
+			SDP:    TimingSDP + "garbage\n" + "m=audio 0 RTP/AVP 0\r\n",
+			Actual: TimingSDP + "m=audio 0 RTP/AVP 0\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMalformedLine, Line: 5, Text: "garbage"},
+			},
+		},
+		{
+			Name:   "DuplicateSessionName",
+			SDP:    BaseSDP + "s=Other\r\n" + "t=0 0\r\n",
+			Actual: BaseSDP + "t=0 0\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningDuplicateLine, Line: 4, Type: 's', Text: "s=Other"},
+			},
+		},
+		{
+			Name:   "MissingTiming",
+			SDP:    BaseSDP + "m=audio 0 RTP/AVP 0\r\n" + "a=sendrecv\r\n",
+			Actual: BaseSDP + "m=audio 0 RTP/AVP 0\r\n" + "a=sendrecv\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMissingTiming, Line: 4, Type: 't'},
+			},
+		},
+		{
+			Name:   "MissingTimingAtEnd",
+			SDP:    BaseSDP,
+			Actual: BaseSDP,
+			Warnings: []ParseWarning{
+				{Kind: WarningMissingTiming, Line: 3, Type: 't'},
+			},
+		},
+		{
+			Name: "MisplacedSessionLine",
+			SDP: MediaNameSDP +
+				"a=sendrecv\r\n" +
+				"e=j.doe@example.com\r\n" +
+				"a=mid:2\r\n",
+			Actual: BaseSDP +
+				"e=j.doe@example.com\r\n" +
+				"t=2873397496 2873404696\r\n" +
+				"m=video 51372 RTP/AVP 99\r\n" +
+				"m=audio 54400 RTP/SAVPF 0 96\r\n" +
+				"m=message 5028 TCP/MSRP *\r\n" +
+				"a=sendrecv\r\n" +
+				"a=mid:2\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMisplacedLine, Line: 9, Type: 'e', Text: "e=j.doe@example.com"},
+			},
+		},
+		{
+			Name: "MisplacedTiming",
+			SDP: BaseSDP +
+				"a=recvonly\r\n" +
+				"t=0 0\r\n",
+			Actual: BaseSDP +
+				"t=0 0\r\n" +
+				"a=recvonly\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMissingTiming, Line: 4, Type: 't'},
+				{Kind: WarningMisplacedLine, Line: 5, Type: 't', Text: "t=0 0"},
+			},
+		},
+		{
+			Name:   "TrailingFields",
+			SDP:    BaseSDP + "c=IN IP4 1.2.3.4 extra\r\n" + "t=0 0 5\r\n",
+			Actual: BaseSDP + "c=IN IP4 1.2.3.4\r\n" + "t=0 0\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMalformedLine, Line: 4, Text: "c=IN IP4 1.2.3.4 extra"},
+				{Kind: WarningMalformedLine, Line: 5, Text: "t=0 0 5"},
+			},
+		},
+		{
+			Name:   "MissingSessionName",
+			SDP:    "v=0\r\n" + "o=- 1 1 IN IP4 0.0.0.0\r\n" + "t=0 0\r\n",
+			Actual: "v=0\r\n" + "o=- 1 1 IN IP4 0.0.0.0\r\n" + "s=\r\n" + "t=0 0\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningMisplacedLine, Line: 3, Type: 't', Text: "t=0 0"},
+				{Kind: WarningMissingSessionName, Line: 3, Type: 's'},
+			},
+		},
+		{
+			Name:   "TrailingWhitespace",
+			SDP:    TimingSDP + "a=sendrecv \t\r\n",
+			Actual: TimingSDP + "a=sendrecv\r\n",
+			Warnings: []ParseWarning{
+				{Kind: WarningTrailingWhitespace, Line: 5, Type: 'a', Text: "a=sendrecv \t"},
+			},
+		},
+	} {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			sd := &SessionDescription{}
+
+			warnings, err := UnmarshalOptions{Lenient: true}.UnmarshalString(test.SDP, sd)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Warnings, warnings)
+
+			actual, err := sd.Marshal()
+			assert.NoError(t, err)
+			assert.Equal(t, test.Actual, string(actual))
+
+			assert.Error(t, sd.UnmarshalString(test.SDP+"x=strict\r\n"))
+		})
+	}
+}
+
+func TestUnmarshalLenientInvalidValue(t *testing.T) {
+	var sd SessionDescription
+	_, err := UnmarshalOptions{Lenient: true}.UnmarshalString(TimingSDP+"b=AS:abc\r\n", &sd)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestUnmarshalLenientNotSDP(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"hello world\r\n",
+		"GET / HTTP/1.1\r\nHost: x\r\n",
+		"s=only a name\r\nt=0 0\r\n",
+	} {
+		var sd SessionDescription
+		_, err := UnmarshalOptions{Lenient: true}.UnmarshalString(value, &sd)
+		assert.ErrorIs(t, err, ErrInvalidSyntax, value)
+
+		var parseErr *ParseError
+		assert.ErrorAs(t, err, &parseErr, value)
+	}
+}
+
+func TestParseWarningString(t *testing.T) {
+	assert.Equal(t,
+		"sdp: line 5: unknown line type: \"x=foo\"",
+		ParseWarning{Kind: WarningUnknownLineType, Line: 5, Type: 'x', Text: "x=foo"}.String(),
+	)
+	assert.Equal(t,
+		"sdp: line 3: missing t= line",
+		ParseWarning{Kind: WarningMissingTiming, Line: 3, Type: 't'}.String(),
+	)
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded indicates a session description that exceeds one of the
+// UnmarshalLimits.
+var ErrLimitExceeded = errors.New("sdp: limit exceeded")
+
+const (
+	defaultMaxSize              = 1 << 20
+	defaultMaxLineLength        = 16 << 10
+	defaultMaxMediaDescriptions = 1024
+	defaultMaxAttributes        = 1024
+	defaultMaxBandwidths        = 16
+	defaultMaxTimeDescriptions  = 64
+	defaultMaxRepeatTimes       = 64
+)
+
+// UnmarshalLimits bounds the resources used to parse a session description,
+// so that hostile input cannot exhaust memory. A zero field selects the
+// default, a negative field disables the limit.
+type UnmarshalLimits struct {
+	// MaxSize is the size of the whole description in bytes. Defaults to
+	// 1 MiB.
+	MaxSize int
+
+	// MaxLineLength is the length of a single line in bytes, excluding the
+	// line ending. Defaults to 16 KiB.
+	MaxLineLength int
+
+	// MaxMediaDescriptions is the number of "m=" sections. Defaults to 1024.
+	MaxMediaDescriptions int
+
+	// MaxAttributes is the number of "a=" lines at session level and in each
+	// media description. Defaults to 1024.
+	MaxAttributes int
+
+	// MaxBandwidths is the number of "b=" lines at session level and in each
+	// media description. Defaults to 16.
+	MaxBandwidths int
+
+	// MaxTimeDescriptions is the number of "t=" lines. Defaults to 64.
+	MaxTimeDescriptions int
+
+	// MaxRepeatTimes is the number of "r=" lines for each "t=" line.
+	// Defaults to 64.
+	MaxRepeatTimes int
+}
+
+func (l UnmarshalLimits) withDefaults() UnmarshalLimits {
+	orDefault := func(value *int, def int) {
+		if *value == 0 {
+			*value = def
+		}
+	}
+
+	orDefault(&l.MaxSize, defaultMaxSize)
+	orDefault(&l.MaxLineLength, defaultMaxLineLength)
+	orDefault(&l.MaxMediaDescriptions, defaultMaxMediaDescriptions)
+	orDefault(&l.MaxAttributes, defaultMaxAttributes)
+	orDefault(&l.MaxBandwidths, defaultMaxBandwidths)
+	orDefault(&l.MaxTimeDescriptions, defaultMaxTimeDescriptions)
+	orDefault(&l.MaxRepeatTimes, defaultMaxRepeatTimes)
+
+	return l
+}
+
+// checkLimit returns an error if count is above limit.
+func checkLimit(count, limit int, what string) error {
+	if limit >= 0 && count > limit {
+		return fmt.Errorf("%w: more than %d %s", ErrLimitExceeded, limit, what)
+	}
+
+	return nil
+}
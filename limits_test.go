@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalLimits(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Limits UnmarshalLimits
+		Value  string
+		Line   int
+	}{
+		{
+			Name:   "Size",
+			Limits: UnmarshalLimits{MaxSize: 16},
+			Value:  BaseSDP,
+			Line:   1,
+		},
+		{
+			Name:   "LineLength",
+			Limits: UnmarshalLimits{MaxLineLength: 10},
+			Value:  SessionInformationSDP,
+			Line:   2,
+		},
+		{
+			Name:   "MediaDescriptions",
+			Limits: UnmarshalLimits{MaxMediaDescriptions: 1},
+			Value:  MediaNameSDP,
+			Line:   6,
+		},
+		{
+			Name:   "SessionAttributes",
+			Limits: UnmarshalLimits{MaxAttributes: 1},
+			Value:  TimingSDP + "a=recvonly\r\na=sendonly\r\n",
+			Line:   6,
+		},
+		{
+			Name:   "MediaAttributes",
+			Limits: UnmarshalLimits{MaxAttributes: 1},
+			Value:  MediaAttributesSDP,
+			Line:   9,
+		},
+		{
+			Name:   "SessionBandwidth",
+			Limits: UnmarshalLimits{MaxBandwidths: 1},
+			Value:  SessionBandwidthSDP,
+			Line:   5,
+		},
+		{
+			Name:   "MediaBandwidth",
+			Limits: UnmarshalLimits{MaxBandwidths: 1},
+			Value:  MediaBandwidthSDP,
+			Line:   9,
+		},
+		{
+			Name:   "TimeDescriptions",
+			Limits: UnmarshalLimits{MaxTimeDescriptions: 1},
+			Value:  TimingSDP + "t=0 0\r\n",
+			Line:   5,
+		},
+		{
+			Name:   "RepeatTimes",
+			Limits: UnmarshalLimits{MaxRepeatTimes: 1},
+			Value:  RepeatTimesSDP,
+			Line:   6,
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			sd := &SessionDescription{}
+			_, err := UnmarshalOptions{Limits: test.Limits}.UnmarshalString(test.Value, sd)
+			assert.ErrorIs(t, err, ErrLimitExceeded)
+
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, ErrLimitExceeded, parseErr.Kind)
+				assert.Equal(t, test.Line, parseErr.Line)
+			}
+
+			unlimited := UnmarshalLimits{
+				MaxSize:              -1,
+				MaxLineLength:        -1,
+				MaxMediaDescriptions: -1,
+				MaxAttributes:        -1,
+				MaxBandwidths:        -1,
+				MaxTimeDescriptions:  -1,
+				MaxRepeatTimes:       -1,
+			}
+			_, err = UnmarshalOptions{Limits: unlimited}.UnmarshalString(test.Value, sd)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUnmarshalDefaultLimits(t *testing.T) {
+	value := MediaNameSDP + strings.Repeat("a=rtcp-fb:99 nack\r\n", defaultMaxAttributes+1)
+
+	sd := &SessionDescription{}
+	assert.ErrorIs(t, sd.UnmarshalString(value), ErrLimitExceeded)
+
+	value = BaseSDP + "i=" + strings.Repeat("x", defaultMaxLineLength) + "\r\n"
+	assert.ErrorIs(t, sd.UnmarshalString(value), ErrLimitExceeded)
+
+	assert.NoError(t, sd.UnmarshalString(CanonicalUnmarshalSDP))
+}
+
+func TestDecoderLimits(t *testing.T) {
+	stream := SessionInformationSDP +
+		BaseSDP + "i=" + strings.Repeat("x", 8192) + "\r\n" + "t=0 0\r\n" +
+		MediaNameSDP +
+		CanonicalUnmarshalSDP
+
+	decoder := NewDecoder(strings.NewReader(stream))
+	decoder.SetOptions(UnmarshalOptions{Limits: UnmarshalLimits{MaxLineLength: 100, MaxSize: 300}})
+
+	var results []error
+	for {
+		var sd SessionDescription
+		err := decoder.Decode(&sd)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		results = append(results, err)
+	}
+
+	if assert.Len(t, results, 4) {
+		assert.NoError(t, results[0])
+		assert.ErrorIs(t, results[1], ErrLimitExceeded)
+		assert.NoError(t, results[2])
+		assert.ErrorIs(t, results[3], ErrLimitExceeded)
+	}
+}
@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+)
+
+// rawLineRef marks the start of a line in the lexed value.
+type rawLineRef struct {
+	start int
+	typ   byte
+	media int
+	extra bool
+}
+
+// rawLine is a line as it appeared in the parsed input.
+type rawLine struct {
+	typ byte
+
+	// text is the line without its line ending, tail is the line ending
+	// followed by any blank lines.
+	text string
+	tail string
+
+	// rendered is the canonical form of the line at parse time. It is empty
+	// for extra lines, which the description does not represent, such as
+	// unknown lines skipped in lenient mode.
+	rendered string
+}
+
+// rawSection holds the original lines of the session level or of a single
+// media description.
+type rawSection struct {
+	// prefix holds blank lines before the first line of the description.
+	prefix string
+	lines  []rawLine
+}
+
+func (l *lexer) recordLine(key byte) {
+	if !l.lossless {
+		return
+	}
+
+	l.rawLines = append(l.rawLines, rawLineRef{
+		start: l.lineStart,
+		typ:   key,
+		media: l.mediaIndex(key),
+	})
+}
+
+// recordSkippedLine marks the last recorded line as one that is kept in the
+// output but not represented in the description. It stays with the section
+// it appeared in.
+func (l *lexer) recordSkippedLine() {
+	if !l.lossless {
+		return
+	}
+
+	ref := &l.rawLines[len(l.rawLines)-1]
+	ref.extra = true
+	ref.media = len(l.desc.MediaDescriptions) - 1
+}
+
+// attachRawLines distributes the recorded lines over the session and its
+// media descriptions.
+func (l *lexer) attachRawLines() {
+	l.desc.raw = &rawSection{}
+	for _, md := range l.desc.MediaDescriptions {
+		md.raw = &rawSection{}
+	}
+
+	for i, ref := range l.rawLines {
+		end := len(l.value)
+		if i+1 < len(l.rawLines) {
+			end = l.rawLines[i+1].start
+		}
+
+		line := rawLine{typ: ref.typ, text: l.value[ref.start:end]}
+		if j := strings.IndexAny(line.text, "\r\n"); j >= 0 {
+			line.text, line.tail = line.text[:j], line.text[j:]
+		}
+		if ref.extra {
+			line.typ = 0
+		}
+
+		section := l.desc.raw
+		if ref.media >= 0 {
+			section = l.desc.MediaDescriptions[ref.media].raw
+		}
+		section.lines = append(section.lines, line)
+	}
+
+	if len(l.rawLines) > 0 {
+		l.desc.raw.prefix = l.value[:l.rawLines[0].start]
+	}
+
+	var marsh marshaller
+	l.desc.marshalSessionInto(&marsh)
+	l.desc.raw.render(marsh)
+	for _, md := range l.desc.MediaDescriptions {
+		marsh = marsh[:0]
+		md.marshalInto(&marsh)
+		md.raw.render(marsh)
+	}
+}
+
+// render pairs every line with the canonical line it was parsed into. Lines
+// of the same type are marshaled in the order they were parsed in.
+func (r *rawSection) render(canonical []byte) {
+	byType := map[byte][]string{}
+	for _, line := range splitLines(canonical) {
+		byType[line[0]] = append(byType[line[0]], line)
+	}
+
+	for i := range r.lines {
+		line := &r.lines[i]
+		if rendered := byType[line.typ]; line.typ != 0 && len(rendered) > 0 {
+			line.rendered = rendered[0]
+			byType[line.typ] = rendered[1:]
+		} else {
+			line.typ = 0
+		}
+	}
+}
+
+// marshalLossless marshals the description keeping the original text of all
+// lines that did not change since parsing.
+func (s *SessionDescription) marshalLossless() []byte {
+	var out []byte
+	s.writeLossless(func(section []byte) error { //nolint:errcheck
+		out = append(out, section...)
+
+		return nil
+	})
+
+	return out
+}
+
+// writeLossless merges the original lines of every section with the current
+// content and passes the sections to write one at a time.
+func (s *SessionDescription) writeLossless(write func([]byte) error) error {
+	eol := s.lineEnding()
+
+	// open is set if the last line written has no line ending, when the
+	// input ended without one. The line ending of a raw line is whatever
+	// newline characters the lexer consumed after it, "\r" included.
+	open := false
+	writeSection := func(lines []string) error {
+		var section []byte
+		for _, line := range lines {
+			if open {
+				// The input ended without a line ending but lines follow now.
+				section = append(section, eol...)
+			}
+			section = append(section, line...)
+			open = line == "" || !isNewline(line[len(line)-1])
+		}
+
+		return write(section)
+	}
+
+	var marsh marshaller
+	s.marshalSessionInto(&marsh)
+	if err := writeSection(s.raw.merge(nil, marsh, eol)); err != nil {
+		return err
+	}
+
+	for _, md := range s.MediaDescriptions {
+		marsh = marsh[:0]
+		md.marshalInto(&marsh)
+		if err := writeSection(md.raw.merge(nil, marsh, eol)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lineEnding returns the line ending of the first original line that has
+// one, "\n" or "\r\n". It is "\r\n" if there is none.
+func (s *SessionDescription) lineEnding() string {
+	sections := []*rawSection{s.raw}
+	for _, md := range s.MediaDescriptions {
+		sections = append(sections, md.raw)
+	}
+
+	for _, section := range sections {
+		if eol := section.lineEnding(); eol != "" {
+			return eol
+		}
+	}
+
+	return "\r\n"
+}
+
+// lineEnding returns the line ending of the first line of the section that
+// has one, or "" if there is none.
+func (r *rawSection) lineEnding() string {
+	if r == nil {
+		return ""
+	}
+
+	for _, line := range r.lines {
+		switch {
+		case strings.HasPrefix(line.tail, "\r\n"):
+			return "\r\n"
+		case strings.HasPrefix(line.tail, "\n"):
+			return "\n"
+		}
+	}
+
+	return ""
+}
+
+// isLossless reports whether any section holds original lines.
+func (s *SessionDescription) isLossless() bool {
+	if s.raw != nil {
+		return true
+	}
+
+	for _, md := range s.MediaDescriptions {
+		if md.raw != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge appends the lines of a section to out. Lines that are unchanged keep
+// their original text, changed lines are rendered again in place of the
+// original and new lines follow the closest line preceding them in canonical
+// order. New lines end in the line ending of the section, or in eol if it
+// has none.
+func (r *rawSection) merge(out []string, canonical []byte, eol string) []string { //nolint:cyclop
+	current := splitLines(canonical)
+	if sectionEOL := r.lineEnding(); sectionEOL != "" {
+		eol = sectionEOL
+	}
+	if r == nil {
+		for _, line := range current {
+			out = append(out, line+eol)
+		}
+
+		return out
+	}
+
+	// matches[i] is the index into current for the raw line i, -1 if the
+	// line was removed and -2 for extra lines.
+	matches := make([]int, len(r.lines))
+	used := make([]bool, len(current))
+
+	for i, line := range r.lines {
+		matches[i] = -1
+		if line.typ == 0 {
+			matches[i] = -2
+
+			continue
+		}
+		for j, rendered := range current {
+			if !used[j] && rendered == line.rendered {
+				matches[i] = j
+				used[j] = true
+
+				break
+			}
+		}
+	}
+
+	for i, line := range r.lines {
+		if matches[i] != -1 {
+			continue
+		}
+		for j, rendered := range current {
+			if !used[j] && lineIdentity(rendered) == lineIdentity(line.rendered) {
+				matches[i] = j
+				used[j] = true
+
+				break
+			}
+		}
+	}
+
+	type entry struct {
+		index int
+		text  string
+	}
+
+	entries := make([]entry, 0, len(current)+len(r.lines))
+	index := -1
+	for i, line := range r.lines {
+		switch {
+		case matches[i] == -1:
+			continue
+		case matches[i] >= 0:
+			index = matches[i]
+		}
+
+		text := line.text + line.tail
+		if matches[i] >= 0 && current[index] != line.rendered {
+			text = current[index] + line.tail
+		}
+		entries = append(entries, entry{index: index, text: text})
+	}
+
+	for j, rendered := range current {
+		if used[j] {
+			continue
+		}
+
+		at := 0
+		for k := len(entries) - 1; k >= 0; k-- {
+			if entries[k].index < j {
+				at = k + 1
+
+				break
+			}
+		}
+
+		entries = append(entries, entry{})
+		copy(entries[at+1:], entries[at:])
+		entries[at] = entry{index: j, text: rendered + eol}
+	}
+
+	if r.prefix != "" && len(entries) > 0 {
+		entries[0].text = r.prefix + entries[0].text
+	}
+
+	for _, e := range entries {
+		out = append(out, e.text)
+	}
+
+	return out
+}
+
+// lineIdentity returns what identifies a line regardless of its value: the
+// type, plus the key for attributes.
+func lineIdentity(line string) string {
+	if strings.HasPrefix(line, "a=") {
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			return line[:i]
+		}
+	}
+
+	if len(line) > 2 {
+		return line[:2]
+	}
+
+	return line
+}
+
+func splitLines(b []byte) []string {
+	lines := strings.Split(string(b), "\r\n")
+
+	return lines[:len(lines)-1]
+}
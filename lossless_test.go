@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const losslessSDP = "\r\n" +
+	"v=0\n" +
+	"o=- 1001 1 IN IP4 10.0.0.1\n" +
+	"s=Camera  stream\n" +
+	"t=0 0\n" +
+	"r=3d 2h 0 21h\n" +
+	"\n" +
+	"a=tool:foo\n" +
+	"m=audio 9 RTP/AVP 0 8\n" +
+	"c=IN IP4 10.0.0.1\n" +
+	"a=rtpmap:0 PCMU/8000\n" +
+	"a=sendrecv\n" +
+	"m=video 9 RTP/AVP 96\n" +
+	"a=rtpmap:96 H264/90000\n" +
+	"a=fmtp:96 packetization-mode=1;profile-level-id=42e01f\n" +
+	"a=recvonly\n"
+
+func parseLossless(t *testing.T, value string) *SessionDescription {
+	t.Helper()
+
+	sd := &SessionDescription{}
+	_, err := UnmarshalOptions{Lossless: true}.UnmarshalString(value, sd)
+	assert.NoError(t, err)
+
+	return sd
+}
+
+func TestLosslessRoundTrip(t *testing.T) {
+	for _, value := range []string{
+		losslessSDP,
+		CanonicalUnmarshalSDP,
+		SessionInformationSDPExtraCRLF,
+		MediaDescriptionOutOfOrderSDP,
+		RepeatTimesSDP,
+	} {
+		sd := parseLossless(t, value)
+
+		actual, err := sd.Marshal()
+		assert.NoError(t, err)
+		assert.Equal(t, value, string(actual))
+		assert.Equal(t, len(value), sd.MarshalSize())
+
+		var buf bytes.Buffer
+		_, err = sd.WriteTo(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, value, buf.String())
+	}
+}
+
+func TestLosslessLenient(t *testing.T) {
+	for _, value := range []string{
+		TimingSDP + "x=unknown\r\n" + "m=audio 9 RTP/AVP 0\r\n" + "a=sendrecv \r\n" + "garbage\n",
+		"v=00\no=0 0 0 IN\ns=\n\r0\n",
+	} {
+		sd := &SessionDescription{}
+		_, err := UnmarshalOptions{Lenient: true, Lossless: true}.UnmarshalString(value, sd)
+		assert.NoError(t, err)
+
+		actual, err := sd.Marshal()
+		assert.NoError(t, err)
+		assert.Equal(t, value, string(actual))
+	}
+}
+
+func TestLosslessTrailingFields(t *testing.T) {
+	value := BaseSDP + "c=IN IP4 1.2.3.4 extra\n" + "t=0 0 5\n" + "m=audio 9 RTP/AVP 0 \n"
+
+	sd := &SessionDescription{}
+	warnings, err := UnmarshalOptions{Lenient: true, Lossless: true}.UnmarshalString(value, sd)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 3)
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, value, string(actual))
+}
+
+func TestLosslessEdits(t *testing.T) {
+	sd := parseLossless(t, losslessSDP)
+
+	sd.Origin.SessionVersion++
+	sd.MediaDescriptions[0].Attributes[1] = NewPropertyAttribute(AttrKeySendOnly)
+	sd.MediaDescriptions[0].Attributes = append(sd.MediaDescriptions[0].Attributes, NewAttribute(AttrKeyMID, "0"))
+	sd.MediaDescriptions[0].Bandwidth = []Bandwidth{{Type: "AS", Bandwidth: 64}}
+	sd.MediaDescriptions[1].Attributes = sd.MediaDescriptions[1].Attributes[:2]
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "\r\n"+
+		"v=0\n"+
+		"o=- 1001 2 IN IP4 10.0.0.1\n"+
+		"s=Camera  stream\n"+
+		"t=0 0\n"+
+		"r=3d 2h 0 21h\n"+
+		"\n"+
+		"a=tool:foo\n"+
+		"m=audio 9 RTP/AVP 0 8\n"+
+		"c=IN IP4 10.0.0.1\n"+
+		"b=AS:64\n"+
+		"a=rtpmap:0 PCMU/8000\n"+
+		"a=sendonly\n"+
+		"a=mid:0\n"+
+		"m=video 9 RTP/AVP 96\n"+
+		"a=rtpmap:96 H264/90000\n"+
+		"a=fmtp:96 packetization-mode=1;profile-level-id=42e01f\n",
+		string(actual))
+}
+
+func TestLosslessMediaChanges(t *testing.T) {
+	sd := parseLossless(t, losslessSDP)
+
+	added := NewJSEPMediaDescription("video", nil).WithCodec(97, "VP8", 90000, 0, "")
+	sd.MediaDescriptions = []*MediaDescription{sd.MediaDescriptions[1], added}
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(actual), "a=fmtp:96 packetization-mode=1;profile-level-id=42e01f\n"+
+		"a=recvonly\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 97\n"+
+		"c=IN IP4 0.0.0.0\n"+
+		"a=rtpmap:97 VP8/90000\n"), string(actual))
+	assert.NotContains(t, string(actual), "m=audio")
+}
+
+func TestLosslessDisabledByDefault(t *testing.T) {
+	var sd SessionDescription
+	assert.NoError(t, sd.UnmarshalString(losslessSDP))
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.NotEqual(t, losslessSDP, string(actual))
+}
+
+func TestLosslessClearedByPlainUnmarshal(t *testing.T) {
+	sd := parseLossless(t, losslessSDP)
+	assert.NoError(t, sd.UnmarshalString(losslessSDP))
+	assert.False(t, sd.isLossless())
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.NotContains(t, strings.ReplaceAll(string(actual), "\r\n", ""), "\n")
+}
@@ -37,9 +37,24 @@ package sdp
 //	b=* (zero or more bandwidth information lines)
 //	k=* (encryption key)
 //	a=* (zero or more media attribute lines)
-func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
+func (s *SessionDescription) Marshal() ([]byte, error) {
+	if s.isLossless() {
+		return s.marshalLossless(), nil
+	}
+
 	marsh := make(marshaller, 0, s.MarshalSize())
+	s.marshalSessionInto(&marsh)
+
+	for _, md := range s.MediaDescriptions {
+		md.marshalInto(&marsh)
+	}
+
+	return marsh, nil
+}
 
+// marshalSessionInto marshals the session level lines, everything before
+// the first media description.
+func (s *SessionDescription) marshalSessionInto(marsh *marshaller) { //nolint:cyclop
 	marsh.addKeyValue("v=", s.Version.marshalInto)
 	marsh.addKeyValue("o=", s.Origin.marshalInto)
 	marsh.addKeyValue("s=", s.SessionName.marshalInto)
@@ -49,9 +64,7 @@ func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
 	}
 
 	if s.URI != nil {
-		marsh = append(marsh, "u="...)
-		marsh = append(marsh, s.URI.String()...)
-		marsh = append(marsh, "\r\n"...)
+		marsh.addKeyValue("u=", s.marshalURIInto)
 	}
 
 	if s.EmailAddress != nil {
@@ -78,14 +91,7 @@ func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
 	}
 
 	if len(s.TimeZones) > 0 {
-		marsh = append(marsh, "z="...)
-		for i, z := range s.TimeZones {
-			if i > 0 {
-				marsh = append(marsh, ' ')
-			}
-			marsh = z.marshalInto(marsh)
-		}
-		marsh = append(marsh, "\r\n"...)
+		marsh.addKeyValue("z=", s.marshalTimeZonesInto)
 	}
 
 	if s.EncryptionKey != nil {
@@ -95,39 +101,71 @@ func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
 	for _, a := range s.Attributes {
 		marsh.addKeyValue("a=", a.marshalInto)
 	}
+}
 
-	for _, md := range s.MediaDescriptions {
-		marsh.addKeyValue("m=", md.MediaName.marshalInto)
+func (s *SessionDescription) marshalURIInto(b []byte) []byte {
+	return append(b, s.URI.String()...)
+}
 
-		if md.MediaTitle != nil {
-			marsh.addKeyValue("i=", md.MediaTitle.marshalInto)
+func (s *SessionDescription) marshalTimeZonesInto(b []byte) []byte {
+	for i, z := range s.TimeZones {
+		if i > 0 {
+			b = append(b, ' ')
 		}
+		b = z.marshalInto(b)
+	}
 
-		if md.ConnectionInformation != nil {
-			marsh.addKeyValue("c=", md.ConnectionInformation.marshalInto)
-		}
+	return b
+}
 
-		for _, b := range md.Bandwidth {
-			marsh.addKeyValue("b=", b.marshalInto)
-		}
+func (d *MediaDescription) marshalInto(marsh *marshaller) {
+	marsh.addKeyValue("m=", d.MediaName.marshalInto)
 
-		if md.EncryptionKey != nil {
-			marsh.addKeyValue("k=", md.EncryptionKey.marshalInto)
-		}
+	if d.MediaTitle != nil {
+		marsh.addKeyValue("i=", d.MediaTitle.marshalInto)
+	}
 
-		for _, a := range md.Attributes {
-			marsh.addKeyValue("a=", a.marshalInto)
-		}
+	if d.ConnectionInformation != nil {
+		marsh.addKeyValue("c=", d.ConnectionInformation.marshalInto)
 	}
 
-	return marsh, nil
+	for _, b := range d.Bandwidth {
+		marsh.addKeyValue("b=", b.marshalInto)
+	}
+
+	if d.EncryptionKey != nil {
+		marsh.addKeyValue("k=", d.EncryptionKey.marshalInto)
+	}
+
+	for _, a := range d.Attributes {
+		marsh.addKeyValue("a=", a.marshalInto)
+	}
 }
 
 // `$type=` and CRLF size.
 const lineBaseSize = 4
 
 // MarshalSize returns the size of the SessionDescription once marshaled.
-func (s *SessionDescription) MarshalSize() (marshalSize int) { //nolint:cyclop
+func (s *SessionDescription) MarshalSize() (marshalSize int) {
+	if s.isLossless() {
+		s.writeLossless(func(section []byte) error { //nolint:errcheck
+			marshalSize += len(section)
+
+			return nil
+		})
+
+		return marshalSize
+	}
+
+	marshalSize = s.sessionMarshalSize()
+	for _, md := range s.MediaDescriptions {
+		marshalSize += md.marshalSize()
+	}
+
+	return marshalSize
+}
+
+func (s *SessionDescription) sessionMarshalSize() (marshalSize int) { //nolint:cyclop
 	marshalSize += lineBaseSize + s.Version.marshalSize()
 	marshalSize += lineBaseSize + s.Origin.marshalSize()
 	marshalSize += lineBaseSize + s.SessionName.marshalSize()
@@ -182,26 +220,28 @@ func (s *SessionDescription) MarshalSize() (marshalSize int) { //nolint:cyclop
 		marshalSize += lineBaseSize + a.marshalSize()
 	}
 
-	for _, md := range s.MediaDescriptions {
-		marshalSize += lineBaseSize + md.MediaName.marshalSize()
-		if md.MediaTitle != nil {
-			marshalSize += lineBaseSize + md.MediaTitle.marshalSize()
-		}
-		if md.ConnectionInformation != nil {
-			marshalSize += lineBaseSize + md.ConnectionInformation.marshalSize()
-		}
+	return marshalSize
+}
 
-		for _, b := range md.Bandwidth {
-			marshalSize += lineBaseSize + b.marshalSize()
-		}
+func (d *MediaDescription) marshalSize() (marshalSize int) {
+	marshalSize += lineBaseSize + d.MediaName.marshalSize()
+	if d.MediaTitle != nil {
+		marshalSize += lineBaseSize + d.MediaTitle.marshalSize()
+	}
+	if d.ConnectionInformation != nil {
+		marshalSize += lineBaseSize + d.ConnectionInformation.marshalSize()
+	}
 
-		if md.EncryptionKey != nil {
-			marshalSize += lineBaseSize + md.EncryptionKey.marshalSize()
-		}
+	for _, b := range d.Bandwidth {
+		marshalSize += lineBaseSize + b.marshalSize()
+	}
 
-		for _, a := range md.Attributes {
-			marshalSize += lineBaseSize + a.marshalSize()
-		}
+	if d.EncryptionKey != nil {
+		marshalSize += lineBaseSize + d.EncryptionKey.marshalSize()
+	}
+
+	for _, a := range d.Attributes {
+		marshalSize += lineBaseSize + a.marshalSize()
 	}
 
 	return marshalSize
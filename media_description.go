@@ -35,6 +35,9 @@ type MediaDescription struct {
 	// a=<attribute>:<value>
 	// https://tools.ietf.org/html/rfc4566#section-5.13
 	Attributes []Attribute
+
+	// Original lines, see UnmarshalOptions.Lossless.
+	raw *rawSection
 }
 
 // Attribute returns the value of an attribute and if it exists.
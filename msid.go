@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidMSID indicates a msid or msid-semantic attribute that does not
+// follow the grammar of RFC 8830.
+var ErrInvalidMSID = errors.New("sdp: invalid msid")
+
+// msidNoStream is the stream ID of a track that is not part of a stream.
+// https://datatracker.ietf.org/doc/html/rfc9429#section-5.2.1
+const msidNoStream = "-"
+
+// MSID represents a msid attribute, which associates the media description
+// with a media stream and a track.
+// https://datatracker.ietf.org/doc/html/rfc8830#section-2
+type MSID struct {
+	// StreamID is empty for a track without a stream, "-" in SDP.
+	StreamID string
+	// TrackID is the optional appdata.
+	TrackID string
+}
+
+// Unmarshal parses a msid attribute. The value may be prefixed with
+// "a=msid:" or "msid:".
+func (m *MSID) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyMsid+":")
+	if !isValidMsid(value) {
+		return fmt.Errorf("%w `%v`", ErrInvalidMSID, raw)
+	}
+
+	streamID, trackID, _ := strings.Cut(value, " ")
+	if streamID == msidNoStream {
+		streamID = ""
+	}
+	*m = MSID{StreamID: streamID, TrackID: trackID}
+
+	return nil
+}
+
+// Marshal returns the msid attribute, "msid:" followed by the value.
+func (m MSID) Marshal() string {
+	return AttrKeyMsid + ":" + m.value()
+}
+
+func (m MSID) String() string {
+	return m.Marshal()
+}
+
+func (m MSID) value() string {
+	value := m.StreamID
+	if value == "" {
+		value = msidNoStream
+	}
+	if m.TrackID != "" {
+		value += " " + m.TrackID
+	}
+
+	return value
+}
+
+// MSIDSemantic represents the legacy msid-semantic attribute, which lists
+// the media streams of the session.
+// https://datatracker.ietf.org/doc/html/draft-ietf-mmusic-msid-16#section-4
+type MSIDSemantic struct {
+	// Token is usually SemanticTokenWebRTCMediaStreams.
+	Token string
+	// StreamIDs may be the single wildcard "*" for all streams.
+	StreamIDs []string
+}
+
+// Unmarshal parses a msid-semantic attribute. The value may be prefixed
+// with "a=msid-semantic:" or "msid-semantic:".
+func (m *MSIDSemantic) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyMsidSemantic+":")
+	fields := strings.Fields(value)
+	if len(fields) == 0 || !isToken(fields[0]) {
+		return fmt.Errorf("%w `%v`", ErrInvalidMSID, raw)
+	}
+
+	*m = MSIDSemantic{Token: fields[0], StreamIDs: fields[1:]}
+
+	return nil
+}
+
+// Marshal returns the msid-semantic attribute, "msid-semantic:" followed by
+// the value.
+func (m MSIDSemantic) Marshal() string {
+	return AttrKeyMsidSemantic + ":" + m.value()
+}
+
+func (m MSIDSemantic) String() string {
+	return m.Marshal()
+}
+
+// value starts with a space, like browsers write it.
+func (m MSIDSemantic) value() string {
+	return " " + strings.Join(append([]string{m.Token}, m.StreamIDs...), " ")
+}
+
+// MSID parses the msid attributes of the media description. A media
+// description may belong to several streams, one per attribute.
+func (d *MediaDescription) MSID() ([]MSID, error) {
+	var msids []MSID
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeyMsid {
+			continue
+		}
+
+		var msid MSID
+		if err := msid.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		msids = append(msids, msid)
+	}
+
+	return msids, nil
+}
+
+// WithMSID adds a msid attribute to the media description.
+func (d *MediaDescription) WithMSID(msid MSID) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyMsid, msid.value())
+}
+
+// MSIDSemantic parses the msid-semantic attribute of the session
+// description. It returns nil if there is none.
+func (s *SessionDescription) MSIDSemantic() (*MSIDSemantic, error) {
+	value, ok := s.Attribute(AttrKeyMsidSemantic)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	semantic := &MSIDSemantic{}
+	if err := semantic.Unmarshal(value); err != nil {
+		return nil, err
+	}
+
+	return semantic, nil
+}
+
+// WithMSIDSemantic adds a msid-semantic attribute to the session
+// description.
+func (s *SessionDescription) WithMSIDSemantic(semantic MSIDSemantic) *SessionDescription {
+	return s.WithValueAttribute(AttrKeyMsidSemantic, semantic.value())
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMSIDUnmarshal(t *testing.T) {
+	for raw, expected := range map[string]MSID{
+		"a=msid:stream track": {StreamID: "stream", TrackID: "track"},
+		"msid:stream":         {StreamID: "stream"},
+		"- track":             {TrackID: "track"},
+	} {
+		var msid MSID
+		if assert.NoError(t, msid.Unmarshal(raw), raw) {
+			assert.Equal(t, expected, msid, raw)
+		}
+	}
+
+	assert.Equal(t, "msid:- track", MSID{TrackID: "track"}.Marshal())
+	assert.Equal(t, "msid:stream", MSID{StreamID: "stream"}.Marshal())
+
+	var msid MSID
+	for _, raw := range []string{"msid:", "msid:a b c", "msid:a  b", "msid:(a)", "msid:" + strings.Repeat("a", 65)} {
+		assert.ErrorIs(t, msid.Unmarshal(raw), ErrInvalidMSID, raw)
+	}
+}
+
+func TestMediaDescriptionMSID(t *testing.T) {
+	md := NewJSEPMediaDescription("audio", nil).
+		WithMSID(MSID{StreamID: "stream0", TrackID: "track0"}).
+		WithMSID(MSID{StreamID: "stream1", TrackID: "track0"})
+	assert.Equal(t, Attribute{Key: "msid", Value: "stream0 track0"}, md.Attributes[0])
+
+	msids, err := md.MSID()
+	assert.NoError(t, err)
+	assert.Equal(t, []MSID{{"stream0", "track0"}, {"stream1", "track0"}}, msids)
+
+	md.WithValueAttribute("msid", "a b c")
+	_, err = md.MSID()
+	assert.ErrorIs(t, err, ErrInvalidMSID)
+}
+
+func TestMSIDSemantic(t *testing.T) {
+	sd := parseDiffSDP(t, jsepOfferSDP)
+
+	semantic, err := sd.MSIDSemantic()
+	assert.NoError(t, err)
+	assert.Equal(t, &MSIDSemantic{Token: SemanticTokenWebRTCMediaStreams, StreamIDs: []string{}}, semantic)
+
+	sd = &SessionDescription{}
+	semantic, err = sd.MSIDSemantic()
+	assert.NoError(t, err)
+	assert.Nil(t, semantic)
+
+	sd.WithMSIDSemantic(MSIDSemantic{Token: SemanticTokenWebRTCMediaStreams, StreamIDs: []string{"*"}})
+	assert.Equal(t, "msid-semantic: WMS *", sd.Attributes[0].String())
+
+	semantic, err = sd.MSIDSemantic()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*"}, semantic.StreamIDs)
+
+	sd.Attributes[0].Value = " "
+	_, err = sd.MSIDSemantic()
+	assert.ErrorIs(t, err, ErrInvalidMSID)
+}
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"sort"
+	"strings"
+)
+
+// attributeOrder is the order Normalize puts attributes in. Attributes not
+// listed take the place of the empty entry, ordered by key. The codec
+// attributes rtpmap, rtcp-fb and fmtp share one place and are grouped by
+// format in the order of the "m=" line.
+var attributeOrder = []string{ //nolint:gochecknoglobals
+	AttrKeyGroup, AttrKeyMsidSemantic, AttrKeyICELite, AttrKeyIdentity,
+	AttrKeyICEUfrag, AttrKeyICEPwd, AttrKeyICEOptions, AttrKeyFingerprint, AttrKeyConnectionSetup, AttrKeyTLSID,
+	AttrKeyMID, AttrKeyExtMapAllowMixed, AttrKeyExtMap,
+	AttrKeySendRecv, AttrKeySendOnly, AttrKeyRecvOnly, AttrKeyInactive,
+	AttrKeyMsid, AttrKeyRTCP, AttrKeyRTCPMux, AttrKeyRTCPRsize,
+	AttrKeyRTPMap,
+	AttrKeySSRCGroup, AttrKeySSRC, AttrKeyRID, AttrKeySimulcast,
+	AttrKeySCTPPort, AttrKeyMaxMessageSize, AttrKeyCrypto,
+	"",
+	AttrKeyCandidate, AttrKeyEndOfCandidates,
+}
+
+// sharedAttributes lists the attributes that mean the same at session level
+// and in a media description, so Normalize may move them between the two.
+var sharedAttributes = map[string]bool{ //nolint:gochecknoglobals
+	AttrKeyICEUfrag: true, AttrKeyICEPwd: true, AttrKeyICEOptions: true,
+	AttrKeyFingerprint: true, AttrKeyConnectionSetup: true, AttrKeyTLSID: true,
+	AttrKeyExtMapAllowMixed: true, AttrKeyExtMap: true,
+	AttrKeySendRecv: true, AttrKeySendOnly: true, AttrKeyRecvOnly: true, AttrKeyInactive: true,
+}
+
+// NormalizeOptions configures Normalize.
+type NormalizeOptions struct {
+	// PushDown copies shared session level attributes, like ICE
+	// credentials, the fingerprint or the direction, into every media
+	// description that does not set them. By default attributes repeated
+	// identically in every media description are hoisted to session level
+	// instead.
+	PushDown bool
+}
+
+// Normalize rewrites the session description in canonical form with the
+// default options, see NormalizeOptions.Normalize.
+func (s *SessionDescription) Normalize() {
+	NormalizeOptions{}.Normalize(s)
+}
+
+// Normalize rewrites the session description in canonical form, so that
+// equivalent descriptions marshal to the same bytes:
+//
+//   - codec names in "a=rtpmap" are lower-cased
+//   - "a=fmtp" parameters are trimmed and sorted, with lower-cased keys
+//   - duplicate "a=rtcp-fb" lines are removed
+//   - shared attributes are hoisted to session level or pushed down, see
+//     PushDown
+//   - attributes are put in a fixed order, keeping the relative order of
+//     attributes with the same key
+//
+// The original lines kept by lossless parsing are dropped, so the
+// description marshals with CRLF line endings.
+func (o NormalizeOptions) Normalize(s *SessionDescription) {
+	s.raw = nil
+	s.Attributes = normalizeCodecAttributes(s.Attributes)
+	for _, md := range s.MediaDescriptions {
+		md.raw = nil
+		md.Attributes = normalizeCodecAttributes(md.Attributes)
+	}
+
+	if len(s.MediaDescriptions) > 0 {
+		if o.PushDown {
+			pushDownAttributes(s)
+		} else {
+			hoistAttributes(s)
+		}
+	}
+
+	sortAttributes(s.Attributes, nil)
+	for _, md := range s.MediaDescriptions {
+		sortAttributes(md.Attributes, md.MediaName.Formats)
+	}
+}
+
+func normalizeCodecAttributes(attributes []Attribute) []Attribute {
+	out := attributes[:0]
+	seen := map[string]bool{}
+	for _, a := range attributes {
+		switch a.Key {
+		case AttrKeyRTPMap:
+			format, codec, _ := strings.Cut(strings.TrimSpace(a.Value), " ")
+			name, rest, found := strings.Cut(strings.TrimSpace(codec), "/")
+			a.Value = format + " " + strings.ToLower(name)
+			if found {
+				a.Value += "/" + rest
+			}
+		case AttrKeyFmtp:
+			format, params, _ := strings.Cut(strings.TrimSpace(a.Value), " ")
+			a.Value = format + " " + normalizeFmtp(params)
+		case AttrKeyRTCPFb:
+			a.Value = strings.Join(strings.Fields(a.Value), " ")
+			if seen[a.Value] {
+				continue
+			}
+			seen[a.Value] = true
+		}
+		out = append(out, a)
+	}
+
+	return out
+}
+
+// normalizeFmtp lower-cases the keys of format specific parameters and
+// sorts them.
+func normalizeFmtp(value string) string {
+	params := ParseFmtpParams(value)
+	for i := range params {
+		params[i].Key = strings.ToLower(params[i].Key)
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].String() < params[j].String()
+	})
+
+	return params.String()
+}
+
+// hoistAttributes moves shared attributes to session level if every media
+// description has the same set of values for their key. A set of values in
+// a media description replaces the session level one, so a key is hoisted
+// with all its values or not at all.
+func hoistAttributes(s *SessionDescription) {
+	var hoisted []string
+	for _, a := range s.MediaDescriptions[0].Attributes {
+		key := sharedAttributeKey(a.Key)
+		if !sharedAttributes[a.Key] || containsString(hoisted, key) {
+			continue
+		}
+
+		values := attributesWithKey(s.MediaDescriptions[0].Attributes, key)
+		everywhere := true
+		for _, md := range s.MediaDescriptions[1:] {
+			everywhere = everywhere && sameAttributes(attributesWithKey(md.Attributes, key), values)
+		}
+		if !everywhere {
+			continue
+		}
+
+		// A different session level value must stay overridden.
+		sessionValues := attributesWithKey(s.Attributes, key)
+		if len(sessionValues) != 0 && !sameAttributes(sessionValues, values) {
+			continue
+		}
+
+		hoisted = append(hoisted, key)
+		if len(sessionValues) == 0 {
+			s.Attributes = append(s.Attributes, values...)
+		}
+	}
+
+	for _, md := range s.MediaDescriptions {
+		md.Attributes = removeAttributes(md.Attributes, func(a Attribute) bool {
+			return sharedAttributes[a.Key] && containsString(hoisted, sharedAttributeKey(a.Key))
+		})
+	}
+}
+
+// sharedAttributeKey returns the key of a shared attribute, with the
+// direction attributes sharing one key, as they replace each other.
+func sharedAttributeKey(key string) string {
+	if isDirectionAttribute(key) {
+		return "direction"
+	}
+
+	return key
+}
+
+func attributesWithKey(attributes []Attribute, key string) []Attribute {
+	var out []Attribute
+	for _, a := range attributes {
+		if sharedAttributeKey(a.Key) == key {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
+
+// sameAttributes reports whether both contain the same attributes,
+// regardless of their order.
+func sameAttributes(a, b []Attribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, attr := range a {
+		if !containsAttribute(b, attr) {
+			return false
+		}
+	}
+	for _, attr := range b {
+		if !containsAttribute(a, attr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pushDownAttributes moves shared session level attributes to every media
+// description that does not set the same key.
+func pushDownAttributes(s *SessionDescription) {
+	var pushed []Attribute
+	for _, a := range s.Attributes {
+		if sharedAttributes[a.Key] {
+			pushed = append(pushed, a)
+		}
+	}
+
+	for _, md := range s.MediaDescriptions {
+		// Media level values override session level ones.
+		overridden := map[string]bool{}
+		for _, a := range md.Attributes {
+			overridden[a.Key] = true
+		}
+		hasDirection := directionLine(md.Attributes) != ""
+
+		for _, a := range pushed {
+			if overridden[a.Key] || (hasDirection && isDirectionAttribute(a.Key)) {
+				continue
+			}
+			md.Attributes = append(md.Attributes, a)
+		}
+	}
+
+	s.Attributes = removeAttributes(s.Attributes, func(a Attribute) bool {
+		return sharedAttributes[a.Key]
+	})
+}
+
+func sortAttributes(attributes []Attribute, formats []string) {
+	rank := func(key string) int {
+		if key == AttrKeyRTCPFb || key == AttrKeyFmtp {
+			key = AttrKeyRTPMap
+		}
+		for i, k := range attributeOrder {
+			if k == key {
+				return i
+			}
+		}
+		for i, k := range attributeOrder {
+			if k == "" {
+				return i
+			}
+		}
+
+		return len(attributeOrder)
+	}
+	codecRank := map[string]int{AttrKeyRTPMap: 0, AttrKeyRTCPFb: 1, AttrKeyFmtp: 2}
+	formatIndex := func(a Attribute) int {
+		format, _, _ := strings.Cut(a.Value, " ")
+		for i, f := range formats {
+			if f == format {
+				return i
+			}
+		}
+
+		return len(formats)
+	}
+
+	sort.SliceStable(attributes, func(i, j int) bool {
+		a, b := attributes[i], attributes[j]
+		if rankA, rankB := rank(a.Key), rank(b.Key); rankA != rankB {
+			return rankA < rankB
+		}
+
+		if _, ok := codecRank[a.Key]; ok {
+			if indexA, indexB := formatIndex(a), formatIndex(b); indexA != indexB {
+				return indexA < indexB
+			}
+
+			return codecRank[a.Key] < codecRank[b.Key]
+		}
+
+		return a.Key < b.Key
+	})
+}
+
+func containsAttribute(attributes []Attribute, attr Attribute) bool {
+	for _, a := range attributes {
+		if a == attr {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeAttributes(attributes []Attribute, remove func(Attribute) bool) []Attribute {
+	out := attributes[:0]
+	for _, a := range attributes {
+		if !remove(a) {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
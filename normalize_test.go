@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const normalizeSDP = "v=0\n" +
+	"o=- 1 2 IN IP4 127.0.0.1\n" +
+	"s=-\n" +
+	"t=0 0\n" +
+	"a=msid-semantic: WMS\n" +
+	"a=group:BUNDLE 0 1\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111\n" +
+	"c=IN IP4 0.0.0.0\n" +
+	"a=rtpmap:111 OPUS/48000/2\n" +
+	"a=mid:0\n" +
+	"a=ice-pwd:pwd\n" +
+	"a=ice-ufrag:ufrag\n" +
+	"a=sendrecv\n" +
+	"a=fmtp:111 useinbandfec=1; minptime=10\n" +
+	"a=setup:actpass\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 97 96\n" +
+	"c=IN IP4 0.0.0.0\n" +
+	"a=ice-ufrag:ufrag\n" +
+	"a=candidate:1 1 udp 2122260223 192.168.1.2 51372 typ host\n" +
+	"a=ice-pwd:pwd\n" +
+	"a=setup:actpass\n" +
+	"a=rtcp-fb:96 nack\n" +
+	"a=rtpmap:96 vp8/90000\n" +
+	"a=rtcp-fb:96  nack\n" +
+	"a=rtpmap:97 H264/90000\n" +
+	"a=fmtp:97 profile-level-id=42e01f;packetization-mode=1;\n" +
+	"a=mid:1\n" +
+	"a=recvonly\n"
+
+func TestNormalize(t *testing.T) {
+	sd := &SessionDescription{}
+	_, err := UnmarshalOptions{Lossless: true}.UnmarshalString(normalizeSDP, sd)
+	assert.NoError(t, err)
+
+	sd.Normalize()
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 1 2 IN IP4 127.0.0.1\r\n"+
+		"s=-\r\n"+
+		"t=0 0\r\n"+
+		"a=group:BUNDLE 0 1\r\n"+
+		"a=msid-semantic: WMS\r\n"+
+		"a=ice-ufrag:ufrag\r\n"+
+		"a=ice-pwd:pwd\r\n"+
+		"a=setup:actpass\r\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:0\r\n"+
+		"a=sendrecv\r\n"+
+		"a=rtpmap:111 opus/48000/2\r\n"+
+		"a=fmtp:111 minptime=10;useinbandfec=1\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 97 96\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:1\r\n"+
+		"a=recvonly\r\n"+
+		"a=rtpmap:97 h264/90000\r\n"+
+		"a=fmtp:97 packetization-mode=1;profile-level-id=42e01f\r\n"+
+		"a=rtpmap:96 vp8/90000\r\n"+
+		"a=rtcp-fb:96 nack\r\n"+
+		"a=candidate:1 1 udp 2122260223 192.168.1.2 51372 typ host\r\n",
+		string(actual))
+
+	// Normalizing is idempotent.
+	sd.Normalize()
+	again, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, actual, again)
+}
+
+func TestNormalizePushDown(t *testing.T) {
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(normalizeSDP))
+
+	sd.Normalize()
+	NormalizeOptions{PushDown: true}.Normalize(sd)
+
+	for _, md := range sd.MediaDescriptions {
+		assert.Equal(t, []Attribute{
+			NewAttribute("ice-ufrag", "ufrag"),
+			NewAttribute("ice-pwd", "pwd"),
+			NewAttribute("setup", "actpass"),
+		}, md.Attributes[:3])
+	}
+	assert.Equal(t, []Attribute{
+		NewAttribute("group", "BUNDLE 0 1"),
+		NewAttribute("msid-semantic", " WMS"),
+	}, sd.Attributes)
+}
+
+func TestNormalizeKeepsOverrides(t *testing.T) {
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(TimingSDP+
+		"a=inactive\r\n"+
+		"a=setup:active\r\n"+
+		"m=audio 9 RTP/AVP 0\r\n"+
+		"a=sendrecv\r\n"+
+		"a=setup:passive\r\n"))
+
+	sd.Normalize()
+	assert.Equal(t, []Attribute{NewAttribute("setup", "active"), NewPropertyAttribute("inactive")}, sd.Attributes)
+	assert.Equal(t, []Attribute{NewAttribute("setup", "passive"), NewPropertyAttribute("sendrecv")},
+		sd.MediaDescriptions[0].Attributes)
+
+	NormalizeOptions{PushDown: true}.Normalize(sd)
+	assert.Empty(t, sd.Attributes)
+	assert.Equal(t, []Attribute{NewAttribute("setup", "passive"), NewPropertyAttribute("sendrecv")},
+		sd.MediaDescriptions[0].Attributes)
+}
+
+func TestNormalizeHoistsWholeSets(t *testing.T) {
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(TimingSDP+
+		"m=audio 9 RTP/AVP 0\r\n"+
+		"a=fingerprint:sha-256 AA\r\n"+
+		"a=fingerprint:sha-1 BB\r\n"+
+		"a=setup:actpass\r\n"+
+		"m=video 9 RTP/AVP 96\r\n"+
+		"a=setup:actpass\r\n"+
+		"a=fingerprint:sha-256 AA\r\n"))
+
+	sd.Normalize()
+	assert.Equal(t, []Attribute{NewAttribute("setup", "actpass")}, sd.Attributes)
+	assert.Equal(t, []Attribute{NewAttribute("fingerprint", "sha-256 AA"), NewAttribute("fingerprint", "sha-1 BB")},
+		sd.MediaDescriptions[0].Attributes)
+	assert.Equal(t, []Attribute{NewAttribute("fingerprint", "sha-256 AA")}, sd.MediaDescriptions[1].Attributes)
+
+	// The same set in another order is hoisted as a whole.
+	sd.MediaDescriptions[1].Attributes = []Attribute{
+		NewAttribute("fingerprint", "sha-1 BB"),
+		NewAttribute("fingerprint", "sha-256 AA"),
+	}
+	sd.Normalize()
+	assert.Equal(t, []Attribute{
+		NewAttribute("fingerprint", "sha-256 AA"),
+		NewAttribute("fingerprint", "sha-1 BB"),
+		NewAttribute("setup", "actpass"),
+	}, sd.Attributes)
+	assert.Empty(t, sd.MediaDescriptions[0].Attributes)
+	assert.Empty(t, sd.MediaDescriptions[1].Attributes)
+}
+
+func TestNormalizeFmtpKeyCase(t *testing.T) {
+	a := &SessionDescription{MediaDescriptions: []*MediaDescription{{
+		MediaName:  MediaName{Media: "video", Protos: []string{"RTP", "AVP"}, Formats: []string{"97"}},
+		Attributes: []Attribute{NewAttribute("fmtp", "97 Profile-Level-Id=42e01f; packetization-mode=1")},
+	}}}
+	b := &SessionDescription{MediaDescriptions: []*MediaDescription{{
+		MediaName:  MediaName{Media: "video", Protos: []string{"RTP", "AVP"}, Formats: []string{"97"}},
+		Attributes: []Attribute{NewAttribute("fmtp", "97 packetization-mode=1;profile-level-id=42e01f")},
+	}}}
+
+	a.Normalize()
+	b.Normalize()
+	assert.Equal(t, b.MediaDescriptions[0].Attributes, a.MediaDescriptions[0].Attributes)
+	assert.Equal(t, "97 packetization-mode=1;profile-level-id=42e01f", a.MediaDescriptions[0].Attributes[0].Value)
+}
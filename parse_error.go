@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var (
+	// ErrInvalidSyntax indicates a line that does not follow the SDP grammar.
+	ErrInvalidSyntax = errors.New("sdp: invalid syntax")
+
+	// ErrInvalidValue indicates a field with a value that is not allowed.
+	ErrInvalidValue = errors.New("sdp: invalid value")
+
+	// ErrInvalidNumericValue indicates a field that should be numeric but is not.
+	ErrInvalidNumericValue = errors.New("sdp: invalid numeric value")
+
+	// ErrInvalidPortValue indicates a media port that is not a number in the
+	// range 0-65535.
+	ErrInvalidPortValue = errors.New("sdp: invalid port value")
+)
+
+// ParseError describes why a session description could not be parsed and
+// where in the input the problem is. It matches its Kind and the underlying
+// error with errors.Is.
+type ParseError struct {
+	// Kind is one of ErrInvalidSyntax, ErrInvalidValue,
+	// ErrInvalidNumericValue, ErrInvalidPortValue or ErrLimitExceeded.
+	Kind error
+
+	// Line is the 1-based number of the offending line.
+	Line int
+
+	// Column is the 1-based byte offset within the line at which parsing
+	// stopped.
+	Column int
+
+	// Type is the line type, for example 'm' for a media line.
+	Type byte
+
+	// Text is the offending line without its line ending.
+	Text string
+
+	// MediaIndex is the index of the media description the line belongs
+	// to, or -1 for session level lines.
+	MediaIndex int
+
+	// Err is the error returned while parsing the line.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v (line %d, column %d: %s)", e.Err, e.Line, e.Column, strconv.QuoteToASCII(e.Text))
+}
+
+// Unwrap returns the Kind and the underlying error.
+func (e *ParseError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// parseError annotates an error returned by a state function with the
+// position of the line being parsed.
+func (l *lexer) parseError(err error) error {
+	parseErr := &ParseError{
+		Kind:   parseErrorKind(err),
+		Line:   l.lineNumber(),
+		Column: l.pos - l.lineStart,
+		Text:   l.currentLine(),
+		Err:    err,
+	}
+
+	if parseErr.Column < 1 {
+		parseErr.Column = 1
+	}
+	if parseErr.Column > len(parseErr.Text) {
+		parseErr.Column = len(parseErr.Text) + 1
+	}
+
+	if l.lineStart < len(l.value) {
+		parseErr.Type = l.value[l.lineStart]
+	}
+	parseErr.MediaIndex = l.mediaIndex(parseErr.Type)
+
+	return parseErr
+}
+
+// mediaIndex returns the index of the media description a line of the given
+// type belongs to at the current position, or -1 for the session level.
+func (l *lexer) mediaIndex(key byte) int {
+	switch key {
+	case 'm':
+		return len(l.desc.MediaDescriptions)
+	case 'i', 'c', 'b', 'k', 'a':
+		return len(l.desc.MediaDescriptions) - 1
+	default:
+		return -1
+	}
+}
+
+func parseErrorKind(err error) error {
+	for _, kind := range []error{
+		ErrLimitExceeded, ErrInvalidPortValue, ErrInvalidNumericValue, ErrInvalidValue, ErrInvalidSyntax,
+	} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	var syntaxErr syntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) {
+		return ErrInvalidSyntax
+	}
+
+	// Remaining errors come from parsing field values, e.g. the URI.
+	return ErrInvalidValue
+}
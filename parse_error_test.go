@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		SDP      string
+		Expected ParseError
+	}{
+		{
+			Name: "UnexpectedLineType",
+			SDP:  TimingSDP + "x=foo\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       5,
+				Column:     2,
+				Type:       'x',
+				Text:       "x=foo",
+				MediaIndex: -1,
+			},
+		},
+		{
+			Name: "InvalidVersion",
+			SDP:  "v=1\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidValue,
+				Line:       1,
+				Column:     3,
+				Type:       'v',
+				Text:       "v=1",
+				MediaIndex: -1,
+			},
+		},
+		{
+			Name: "InvalidPort",
+			SDP:  MediaNameSDP + "m=audio 70000 RTP/AVP 0\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidPortValue,
+				Line:       8,
+				Column:     14,
+				Type:       'm',
+				Text:       "m=audio 70000 RTP/AVP 0",
+				MediaIndex: 3,
+			},
+		},
+		{
+			Name: "InvalidMediaBandwidth",
+			SDP:  MediaNameSDP + "b=AS:x\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       8,
+				Column:     7,
+				Type:       'b',
+				Text:       "b=AS:x",
+				MediaIndex: 2,
+			},
+		},
+		{
+			Name: "InvalidNumericValue",
+			SDP:  TimingSDP + "m=audio 9 RTP/FOO 0\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidNumericValue,
+				Line:       5,
+				Column:     18,
+				Type:       'm',
+				Text:       "m=audio 9 RTP/FOO 0",
+				MediaIndex: 0,
+			},
+		},
+		{
+			Name: "InvalidTiming",
+			SDP:  BaseSDP + "t=12a 0\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       4,
+				Column:     5,
+				Type:       't',
+				Text:       "t=12a 0",
+				MediaIndex: -1,
+			},
+		},
+		{
+			Name: "TrailingField",
+			SDP:  MediaNameSDP + "c=IN IP4 1.2.3.4 extra\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       8,
+				Column:     18,
+				Type:       'c',
+				Text:       "c=IN IP4 1.2.3.4 extra",
+				MediaIndex: 2,
+			},
+		},
+		{
+			Name: "TrailingTimingField",
+			SDP:  BaseSDP + "t=0 0 5\r\n",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       4,
+				Column:     7,
+				Type:       't',
+				Text:       "t=0 0 5",
+				MediaIndex: -1,
+			},
+		},
+		{
+			Name: "UnterminatedLine",
+			SDP:  BaseSDP + "t=0 0\r\na=recvonly",
+			Expected: ParseError{
+				Kind:       ErrInvalidSyntax,
+				Line:       5,
+				Column:     10,
+				Type:       'a',
+				Text:       "a=recvonly",
+				MediaIndex: -1,
+			},
+		},
+	} {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			var sd SessionDescription
+			err := sd.UnmarshalString(test.SDP)
+
+			var parseErr *ParseError
+			if !assert.True(t, errors.As(err, &parseErr)) {
+				return
+			}
+			assert.ErrorIs(t, err, test.Expected.Kind)
+
+			test.Expected.Err = parseErr.Err
+			assert.Equal(t, test.Expected, *parseErr)
+		})
+	}
+}
+
+func TestParseErrorString(t *testing.T) {
+	var sd SessionDescription
+	err := sd.UnmarshalString(MediaNameSDP + "m=audio 70000 RTP/AVP 0\r\n")
+	assert.EqualError(t, err,
+		"sdp: invalid port value `70000` (line 8, column 14: \"m=audio 70000 RTP/AVP 0\")")
+}
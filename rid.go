@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRID indicates a rid attribute that does not follow the grammar
+// of RFC 8851.
+var ErrInvalidRID = errors.New("sdp: invalid rid")
+
+// RIDDirection is the direction of a RID.
+type RIDDirection int
+
+const (
+	// RIDDirectionSend is for a stream the endpoint sends.
+	RIDDirectionSend RIDDirection = iota + 1
+	// RIDDirectionRecv is for a stream the endpoint receives.
+	RIDDirectionRecv
+)
+
+func (d RIDDirection) String() string {
+	switch d {
+	case RIDDirectionSend:
+		return "send"
+	case RIDDirectionRecv:
+		return "recv"
+	default:
+		return "Unknown"
+	}
+}
+
+// Reverse returns the direction as seen by the other endpoint.
+func (d RIDDirection) Reverse() RIDDirection {
+	switch d {
+	case RIDDirectionSend:
+		return RIDDirectionRecv
+	case RIDDirectionRecv:
+		return RIDDirectionSend
+	default:
+		return d
+	}
+}
+
+// RIDParam is a restriction of a RID that RID does not represent with a
+// field. An empty Value marshals without "=".
+type RIDParam struct {
+	Key   string
+	Value string
+}
+
+// RID represents a rid attribute. Zero restriction fields are omitted.
+// https://datatracker.ietf.org/doc/html/rfc8851#section-10
+type RID struct {
+	ID        string
+	Direction RIDDirection
+
+	// PayloadTypes restricts the RID to some formats of the media
+	// description.
+	PayloadTypes []uint8
+
+	MaxWidth  uint32
+	MaxHeight uint32
+	MaxFPS    float64
+	MaxFS     uint32
+	MaxBR     uint32
+	MaxPPS    uint32
+
+	// Depend lists the RIDs the stream depends on.
+	Depend []string
+
+	// Params holds all other restrictions in order.
+	Params []RIDParam
+}
+
+// Unmarshal parses a rid attribute. The value may be prefixed with
+// "a=rid:" or "rid:".
+func (r *RID) Unmarshal(raw string) error { //nolint:cyclop
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeyRID+":")
+	fields := strings.Fields(value)
+	if len(fields) < 2 || len(fields) > 3 || !isRIDID(fields[0]) {
+		return fmt.Errorf("%w `%v`", ErrInvalidRID, raw)
+	}
+
+	rid := RID{ID: fields[0]}
+	switch fields[1] {
+	case "send":
+		rid.Direction = RIDDirectionSend
+	case "recv":
+		rid.Direction = RIDDirectionRecv
+	default:
+		return fmt.Errorf("%w: direction `%v`", ErrInvalidRID, fields[1])
+	}
+
+	if len(fields) == 2 {
+		*r = rid
+
+		return nil
+	}
+
+	for i, param := range strings.Split(fields[2], ";") {
+		key, value, _ := strings.Cut(param, "=")
+		var err error
+		switch key {
+		case "pt":
+			if i != 0 {
+				return fmt.Errorf("%w: pt not first in `%v`", ErrInvalidRID, fields[2])
+			}
+			for _, format := range strings.Split(value, ",") {
+				payloadType, parseErr := strconv.ParseUint(format, 10, 8)
+				if parseErr != nil {
+					return fmt.Errorf("%w: pt `%v`", ErrInvalidRID, value)
+				}
+				rid.PayloadTypes = append(rid.PayloadTypes, uint8(payloadType))
+			}
+		case "max-width":
+			rid.MaxWidth, err = parseRIDUint(key, value)
+		case "max-height":
+			rid.MaxHeight, err = parseRIDUint(key, value)
+		case "max-fps":
+			rid.MaxFPS, err = strconv.ParseFloat(value, 64)
+			if err != nil || rid.MaxFPS < 0 {
+				err = fmt.Errorf("%w: %s `%v`", ErrInvalidRID, key, value)
+			}
+		case "max-fs":
+			rid.MaxFS, err = parseRIDUint(key, value)
+		case "max-br":
+			rid.MaxBR, err = parseRIDUint(key, value)
+		case "max-pps":
+			rid.MaxPPS, err = parseRIDUint(key, value)
+		case "depend":
+			rid.Depend = strings.Split(value, ",")
+			for _, id := range rid.Depend {
+				if !isRIDID(id) {
+					err = fmt.Errorf("%w: depend `%v`", ErrInvalidRID, value)
+				}
+			}
+		default:
+			if key == "" {
+				err = fmt.Errorf("%w: empty restriction in `%v`", ErrInvalidRID, fields[2])
+			}
+			rid.Params = append(rid.Params, RIDParam{Key: key, Value: value})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	*r = rid
+
+	return nil
+}
+
+// Marshal returns the rid attribute, "rid:" followed by the value.
+func (r RID) Marshal() string {
+	return AttrKeyRID + ":" + r.value()
+}
+
+func (r RID) String() string {
+	return r.Marshal()
+}
+
+func (r RID) value() string {
+	var params []string
+	if len(r.PayloadTypes) > 0 {
+		formats := make([]string, len(r.PayloadTypes))
+		for i, payloadType := range r.PayloadTypes {
+			formats[i] = strconv.Itoa(int(payloadType))
+		}
+		params = append(params, "pt="+strings.Join(formats, ","))
+	}
+
+	appendUint := func(key string, value uint32) {
+		if value != 0 {
+			params = append(params, key+"="+strconv.FormatUint(uint64(value), 10))
+		}
+	}
+	appendUint("max-width", r.MaxWidth)
+	appendUint("max-height", r.MaxHeight)
+	if r.MaxFPS != 0 {
+		params = append(params, "max-fps="+strconv.FormatFloat(r.MaxFPS, 'f', -1, 64))
+	}
+	appendUint("max-fs", r.MaxFS)
+	appendUint("max-br", r.MaxBR)
+	appendUint("max-pps", r.MaxPPS)
+	if len(r.Depend) > 0 {
+		params = append(params, "depend="+strings.Join(r.Depend, ","))
+	}
+	for _, param := range r.Params {
+		if param.Value == "" {
+			params = append(params, param.Key)
+		} else {
+			params = append(params, param.Key+"="+param.Value)
+		}
+	}
+
+	value := r.ID + " " + r.Direction.String()
+	if len(params) > 0 {
+		value += " " + strings.Join(params, ";")
+	}
+
+	return value
+}
+
+// Reverse returns the RID as it appears in an answer, with the direction
+// reversed.
+func (r RID) Reverse() RID {
+	r.Direction = r.Direction.Reverse()
+
+	return r
+}
+
+// RIDs parses the rid attributes of the media description.
+func (d *MediaDescription) RIDs() ([]RID, error) {
+	var rids []RID
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeyRID {
+			continue
+		}
+
+		var rid RID
+		if err := rid.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		rids = append(rids, rid)
+	}
+
+	return rids, nil
+}
+
+// WithRID adds a rid attribute to the media description.
+func (d *MediaDescription) WithRID(rid RID) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyRID, rid.value())
+}
+
+func parseRIDUint(key, value string) (uint32, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s `%v`", ErrInvalidRID, key, value)
+	}
+
+	return uint32(n), nil
+}
+
+// isRIDID reports whether value is a rid-id, that is 1*(alpha-numeric /
+// "-" / "_").
+func isRIDID(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9', ch == '-', ch == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
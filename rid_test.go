@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRIDUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Raw      string
+		Expected RID
+		Marshal  string
+	}{
+		{"rid:hi send", RID{ID: "hi", Direction: RIDDirectionSend}, "rid:hi send"},
+		{
+			"a=rid:1 recv pt=96,97;max-width=1280;max-height=720;max-fps=29.97;max-br=2500000",
+			RID{
+				ID: "1", Direction: RIDDirectionRecv, PayloadTypes: []uint8{96, 97},
+				MaxWidth: 1280, MaxHeight: 720, MaxFPS: 29.97, MaxBR: 2500000,
+			},
+			"rid:1 recv pt=96,97;max-width=1280;max-height=720;max-fps=29.97;max-br=2500000",
+		},
+		{
+			"low_1 send max-fs=3600;max-pps=108000;depend=mid-1,hi;max-bpp=0.5;foo",
+			RID{
+				ID: "low_1", Direction: RIDDirectionSend, MaxFS: 3600, MaxPPS: 108000,
+				Depend: []string{"mid-1", "hi"},
+				Params: []RIDParam{{Key: "max-bpp", Value: "0.5"}, {Key: "foo"}},
+			},
+			"rid:low_1 send max-fs=3600;max-pps=108000;depend=mid-1,hi;max-bpp=0.5;foo",
+		},
+	} {
+		var rid RID
+		if assert.NoError(t, rid.Unmarshal(test.Raw), test.Raw) {
+			assert.Equal(t, test.Expected, rid, test.Raw)
+			assert.Equal(t, test.Marshal, rid.Marshal(), test.Raw)
+		}
+	}
+
+	for _, raw := range []string{
+		"rid:",
+		"rid:hi",
+		"rid:hi sendrecv",
+		"rid:h.i send",
+		"rid:hi send pt=96 extra",
+		"rid:hi send pt=x",
+		"rid:hi send max-width=1280;pt=96",
+		"rid:hi send max-width=-1",
+		"rid:hi send max-fps=fast",
+		"rid:hi send depend=a,",
+		"rid:hi send max-width=1;;foo",
+	} {
+		var rid RID
+		assert.ErrorIs(t, rid.Unmarshal(raw), ErrInvalidRID, raw)
+	}
+}
+
+func TestMediaDescriptionRIDs(t *testing.T) {
+	hi := RID{ID: "hi", Direction: RIDDirectionSend, MaxWidth: 1280}
+	lo := RID{ID: "lo", Direction: RIDDirectionSend, MaxWidth: 320}
+	md := NewJSEPMediaDescription("video", nil).WithRID(hi).WithRID(lo)
+
+	assert.Equal(t, Attribute{Key: "rid", Value: "hi send max-width=1280"}, md.Attributes[0])
+
+	rids, err := md.RIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []RID{hi, lo}, rids)
+	assert.Equal(t, RIDDirectionRecv, rids[0].Reverse().Direction)
+	assert.Equal(t, RIDDirectionSend, rids[0].Reverse().Reverse().Direction)
+
+	md.WithValueAttribute("rid", "bogus")
+	_, err = md.RIDs()
+	assert.ErrorIs(t, err, ErrInvalidRID)
+}
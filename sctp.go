@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const dataChannelFormat = "webrtc-datachannel"
+
+// DefaultMaxMessageSize is the maximum message size of an SCTP media
+// description without "a=max-message-size".
+// https://datatracker.ietf.org/doc/html/rfc8841#section-6.1
+const DefaultMaxMessageSize = 65536
+
+// ErrInvalidSCTP indicates an SCTP media description with a malformed
+// sctp-port, max-message-size or sctpmap attribute.
+var ErrInvalidSCTP = errors.New("sdp: invalid sctp attribute")
+
+// NewJSEPDataChannelMediaDescription creates a media description for data
+// channels, "m=application 9 UDP/DTLS/SCTP webrtc-datachannel" with the
+// given SCTP port. A nil maxMessageSize omits "a=max-message-size", so
+// DefaultMaxMessageSize applies, and 0 means that the message size is not
+// limited.
+// https://datatracker.ietf.org/doc/html/rfc8841#section-4.1
+func NewJSEPDataChannelMediaDescription(sctpPort uint16, maxMessageSize *uint64) *MediaDescription {
+	d := &MediaDescription{
+		MediaName: MediaName{
+			Media:   "application",
+			Port:    RangedPort{Value: 9},
+			Protos:  []string{"UDP", "DTLS", "SCTP"},
+			Formats: []string{dataChannelFormat},
+		},
+		ConnectionInformation: &ConnectionInformation{
+			NetworkType: "IN",
+			AddressType: "IP4",
+			Address: &Address{
+				Address: "0.0.0.0",
+			},
+		},
+	}
+
+	d.WithValueAttribute(AttrKeySCTPPort, strconv.Itoa(int(sctpPort)))
+	if maxMessageSize != nil {
+		d.WithValueAttribute(AttrKeyMaxMessageSize, strconv.FormatUint(*maxMessageSize, 10))
+	}
+
+	return d
+}
+
+// IsDataChannel reports whether the media description carries data
+// channels, in the current or the legacy form.
+func (d *MediaDescription) IsDataChannel() bool {
+	if d.MediaName.Media != "application" {
+		return false
+	}
+
+	for _, format := range d.MediaName.Formats {
+		if format == dataChannelFormat {
+			return true
+		}
+	}
+
+	return d.isLegacySCTP()
+}
+
+// isLegacySCTP reports whether the media description uses the SCTP port as
+// format and "a=sctpmap" with the webrtc-datachannel protocol, as in
+// draft-ietf-mmusic-sctp-sdp-05.
+func (d *MediaDescription) isLegacySCTP() bool {
+	value, ok := d.Attribute(AttrKeySCTPMap)
+	if !ok {
+		return false
+	}
+
+	fields := strings.Fields(value)
+
+	return len(fields) >= 2 && fields[1] == dataChannelFormat
+}
+
+// SCTPPort returns the SCTP port of the media description, from
+// "a=sctp-port" or the legacy "a=sctpmap". It returns 0 without an error if
+// there is none.
+func (d *MediaDescription) SCTPPort() (uint16, error) {
+	if value, ok := d.Attribute(AttrKeySCTPPort); ok {
+		return parseSCTPPort(value)
+	}
+
+	if value, ok := d.Attribute(AttrKeySCTPMap); ok {
+		port, _, _ := strings.Cut(value, " ")
+
+		return parseSCTPPort(port)
+	}
+
+	return 0, nil
+}
+
+// MaxMessageSize returns the value of "a=max-message-size", or
+// DefaultMaxMessageSize if there is none. A value of 0 means that the
+// message size is not limited.
+func (d *MediaDescription) MaxMessageSize() (uint64, error) {
+	value, ok := d.Attribute(AttrKeyMaxMessageSize)
+	if !ok {
+		return DefaultMaxMessageSize, nil
+	}
+
+	size, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s `%v`", ErrInvalidSCTP, AttrKeyMaxMessageSize, value)
+	}
+
+	return size, nil
+}
+
+// UpgradeLegacySCTP converts a data channel media description in the
+// legacy form, "DTLS/SCTP 5000" with "a=sctpmap:5000 webrtc-datachannel
+// 1024", to "UDP/DTLS/SCTP webrtc-datachannel" with "a=sctp-port:5000". The
+// number of streams of the sctpmap has no equivalent and is dropped. Other
+// media descriptions, including ones in the current form, are left
+// unchanged.
+func (d *MediaDescription) UpgradeLegacySCTP() error {
+	if !d.isLegacySCTP() {
+		return nil
+	}
+
+	port, err := d.SCTPPort()
+	if err != nil {
+		return err
+	}
+
+	d.MediaName.Protos = []string{"UDP", "DTLS", "SCTP"}
+	d.MediaName.Formats = []string{dataChannelFormat}
+	d.Attributes = removeAttributes(d.Attributes, func(a Attribute) bool {
+		return a.Key == AttrKeySCTPMap || a.Key == AttrKeySCTPPort
+	})
+	d.WithValueAttribute(AttrKeySCTPPort, strconv.Itoa(int(port)))
+
+	return nil
+}
+
+func parseSCTPPort(value string) (uint16, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s `%v`", ErrInvalidSCTP, AttrKeySCTPPort, value)
+	}
+
+	return uint16(port), nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSEPDataChannelMediaDescription(t *testing.T) {
+	size := uint64(262144)
+	md := NewJSEPDataChannelMediaDescription(5000, &size).WithValueAttribute(AttrKeyMID, "0")
+
+	data, err := (&SessionDescription{MediaDescriptions: []*MediaDescription{md}}).Marshal()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "m=application 9 UDP/DTLS/SCTP webrtc-datachannel\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=sctp-port:5000\r\n"+
+		"a=max-message-size:262144\r\n"+
+		"a=mid:0\r\n")
+
+	assert.True(t, md.IsDataChannel())
+	port, err := md.SCTPPort()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(5000), port)
+	size, err = md.MaxMessageSize()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(262144), size)
+
+	unlimited := uint64(0)
+	md = NewJSEPDataChannelMediaDescription(5000, &unlimited)
+	value, ok := md.Attribute("max-message-size")
+	assert.True(t, ok)
+	assert.Equal(t, "0", value)
+	size, err = md.MaxMessageSize()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), size)
+
+	md = NewJSEPDataChannelMediaDescription(5000, nil)
+	size, err = md.MaxMessageSize()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(DefaultMaxMessageSize), size)
+
+	md.WithValueAttribute("max-message-size", "-1")
+	_, err = md.MaxMessageSize()
+	assert.ErrorIs(t, err, ErrInvalidSCTP)
+
+	audio := NewJSEPMediaDescription("audio", nil)
+	assert.False(t, audio.IsDataChannel())
+	port, err = audio.SCTPPort()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0), port)
+}
+
+func TestUpgradeLegacySCTP(t *testing.T) {
+	md := &MediaDescription{
+		MediaName: MediaName{
+			Media:   "application",
+			Port:    RangedPort{Value: 9},
+			Protos:  []string{"DTLS", "SCTP"},
+			Formats: []string{"5000"},
+		},
+		Attributes: []Attribute{
+			NewAttribute("sctpmap", "5000 webrtc-datachannel 1024"),
+			NewAttribute("mid", "data"),
+		},
+	}
+
+	assert.True(t, md.IsDataChannel())
+	port, err := md.SCTPPort()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(5000), port)
+
+	assert.NoError(t, md.UpgradeLegacySCTP())
+	assert.Equal(t, []string{"UDP", "DTLS", "SCTP"}, md.MediaName.Protos)
+	assert.Equal(t, []string{"webrtc-datachannel"}, md.MediaName.Formats)
+	assert.Equal(t, []Attribute{NewAttribute("mid", "data"), NewAttribute("sctp-port", "5000")}, md.Attributes)
+
+	// The current form is left unchanged.
+	assert.NoError(t, md.UpgradeLegacySCTP())
+	assert.Len(t, md.Attributes, 2)
+
+	// An sctpmap of another protocol is not a data channel.
+	md.Attributes = []Attribute{NewAttribute("sctpmap", "5000 t38 1024")}
+	md.MediaName.Protos, md.MediaName.Formats = []string{"DTLS", "SCTP"}, []string{"5000"}
+	assert.False(t, md.IsDataChannel())
+	assert.NoError(t, md.UpgradeLegacySCTP())
+	assert.Equal(t, []string{"5000"}, md.MediaName.Formats)
+
+	md.Attributes = []Attribute{NewAttribute("sctpmap", "x webrtc-datachannel 1024")}
+	assert.ErrorIs(t, md.UpgradeLegacySCTP(), ErrInvalidSCTP)
+}
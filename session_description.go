@@ -67,6 +67,9 @@ type SessionDescription struct {
 
 	// https://tools.ietf.org/html/rfc4566#section-5.14
 	MediaDescriptions []*MediaDescription
+
+	// Original session level lines, see UnmarshalOptions.Lossless.
+	raw *rawSection
 }
 
 // Attribute returns the value of an attribute and if it exists.
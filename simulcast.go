@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSimulcast indicates a simulcast attribute that does not follow
+// the grammar of RFC 8853.
+var ErrInvalidSimulcast = errors.New("sdp: invalid simulcast")
+
+// SimulcastRID is a RID in a simulcast attribute.
+type SimulcastRID struct {
+	ID string
+	// Paused is marked with a leading "~".
+	Paused bool
+}
+
+// SimulcastStream is a simulcast stream, one or more alternative RIDs in
+// order of preference.
+type SimulcastStream []SimulcastRID
+
+// Simulcast represents a simulcast attribute. Streams are listed in order
+// of preference.
+// https://datatracker.ietf.org/doc/html/rfc8853#section-5.1
+type Simulcast struct {
+	Send []SimulcastStream
+	Recv []SimulcastStream
+}
+
+// Unmarshal parses a simulcast attribute. The value may be prefixed with
+// "a=simulcast:" or "simulcast:".
+func (s *Simulcast) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeySimulcast+":")
+	fields := strings.Fields(value)
+	if len(fields) != 2 && len(fields) != 4 {
+		return fmt.Errorf("%w `%v`", ErrInvalidSimulcast, raw)
+	}
+
+	var simulcast Simulcast
+	for i := 0; i < len(fields); i += 2 {
+		streams, err := parseSimulcastStreams(fields[i+1])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case fields[i] == "send" && simulcast.Send == nil:
+			simulcast.Send = streams
+		case fields[i] == "recv" && simulcast.Recv == nil:
+			simulcast.Recv = streams
+		default:
+			return fmt.Errorf("%w: direction `%v`", ErrInvalidSimulcast, fields[i])
+		}
+	}
+
+	*s = simulcast
+
+	return nil
+}
+
+// Marshal returns the simulcast attribute, "simulcast:" followed by the
+// value.
+func (s Simulcast) Marshal() string {
+	return AttrKeySimulcast + ":" + s.value()
+}
+
+func (s Simulcast) String() string {
+	return s.Marshal()
+}
+
+func (s Simulcast) value() string {
+	var parts []string
+	if len(s.Send) > 0 {
+		parts = append(parts, "send", marshalSimulcastStreams(s.Send))
+	}
+	if len(s.Recv) > 0 {
+		parts = append(parts, "recv", marshalSimulcastStreams(s.Recv))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Reverse returns the simulcast attribute as it appears in an answer, with
+// send and recv swapped.
+func (s Simulcast) Reverse() Simulcast {
+	return Simulcast{Send: s.Recv, Recv: s.Send}
+}
+
+// Simulcast parses the simulcast attribute of the media description. It
+// returns nil if there is none.
+func (d *MediaDescription) Simulcast() (*Simulcast, error) {
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeySimulcast {
+			continue
+		}
+
+		simulcast := &Simulcast{}
+		if err := simulcast.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+
+		return simulcast, nil
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// WithSimulcast adds a simulcast attribute to the media description.
+func (d *MediaDescription) WithSimulcast(simulcast Simulcast) *MediaDescription {
+	return d.WithValueAttribute(AttrKeySimulcast, simulcast.value())
+}
+
+func parseSimulcastStreams(value string) ([]SimulcastStream, error) {
+	var streams []SimulcastStream
+	for _, alternatives := range strings.Split(value, ";") {
+		var stream SimulcastStream
+		for _, id := range strings.Split(alternatives, ",") {
+			rid := SimulcastRID{ID: strings.TrimPrefix(id, "~")}
+			rid.Paused = len(rid.ID) != len(id)
+			if !isRIDID(rid.ID) {
+				return nil, fmt.Errorf("%w: rid `%v`", ErrInvalidSimulcast, id)
+			}
+			stream = append(stream, rid)
+		}
+		streams = append(streams, stream)
+	}
+
+	return streams, nil
+}
+
+func marshalSimulcastStreams(streams []SimulcastStream) string {
+	var b strings.Builder
+	for i, stream := range streams {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		for j, rid := range stream {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			if rid.Paused {
+				b.WriteByte('~')
+			}
+			b.WriteString(rid.ID)
+		}
+	}
+
+	return b.String()
+}
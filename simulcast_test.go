@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulcastUnmarshal(t *testing.T) {
+	var simulcast Simulcast
+	assert.NoError(t, simulcast.Unmarshal("a=simulcast:recv 1;~2,3 send 4"))
+	assert.Equal(t, Simulcast{
+		Send: []SimulcastStream{{{ID: "4"}}},
+		Recv: []SimulcastStream{
+			{{ID: "1"}},
+			{{ID: "2", Paused: true}, {ID: "3"}},
+		},
+	}, simulcast)
+	assert.Equal(t, "simulcast:send 4 recv 1;~2,3", simulcast.Marshal())
+	assert.Equal(t, "simulcast:send 1;~2,3 recv 4", simulcast.Reverse().Marshal())
+
+	assert.NoError(t, simulcast.Unmarshal("send hi;mid;lo"))
+	assert.Nil(t, simulcast.Recv)
+	assert.Len(t, simulcast.Send, 3)
+
+	for _, raw := range []string{
+		"simulcast:",
+		"simulcast:send",
+		"simulcast:send 1 send 2",
+		"simulcast:both 1",
+		"simulcast:send 1;;2",
+		"simulcast:send 1,~",
+		"simulcast:send 1 recv",
+	} {
+		assert.ErrorIs(t, simulcast.Unmarshal(raw), ErrInvalidSimulcast, raw)
+	}
+}
+
+func TestMediaDescriptionSimulcast(t *testing.T) {
+	md := NewJSEPMediaDescription("video", nil)
+
+	simulcast, err := md.Simulcast()
+	assert.NoError(t, err)
+	assert.Nil(t, simulcast)
+
+	offer := Simulcast{Send: []SimulcastStream{{{ID: "hi"}}, {{ID: "lo", Paused: true}}}}
+	md.WithSimulcast(offer)
+	assert.Equal(t, Attribute{Key: "simulcast", Value: "send hi;~lo"}, md.Attributes[0])
+
+	simulcast, err = md.Simulcast()
+	assert.NoError(t, err)
+	assert.Equal(t, &offer, simulcast)
+
+	answer := NewJSEPMediaDescription("video", nil).WithSimulcast(simulcast.Reverse())
+	assert.Equal(t, Attribute{Key: "simulcast", Value: "recv hi;~lo"}, answer.Attributes[0])
+}
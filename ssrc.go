@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSSRC indicates a ssrc or ssrc-group attribute that does not
+// follow the grammar of RFC 5576.
+var ErrInvalidSSRC = errors.New("sdp: invalid ssrc")
+
+// SSRCAttribute represents a ssrc attribute, a source attribute of a
+// synchronization source.
+// https://datatracker.ietf.org/doc/html/rfc5576#section-4.1
+type SSRCAttribute struct {
+	SSRC      uint32
+	Attribute string
+	// Value is empty for attributes without a value.
+	Value string
+}
+
+// Unmarshal parses a ssrc attribute. The value may be prefixed with
+// "a=ssrc:" or "ssrc:".
+func (s *SSRCAttribute) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeySSRC+":")
+	ssrc, attribute, found := strings.Cut(value, " ")
+	if !found || attribute == "" {
+		return fmt.Errorf("%w `%v`", ErrInvalidSSRC, raw)
+	}
+
+	id, err := strconv.ParseUint(ssrc, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w: ssrc `%v`", ErrInvalidSSRC, ssrc)
+	}
+
+	name, attrValue, _ := strings.Cut(attribute, ":")
+	if !isToken(name) {
+		return fmt.Errorf("%w: attribute `%v`", ErrInvalidSSRC, name)
+	}
+
+	*s = SSRCAttribute{SSRC: uint32(id), Attribute: name, Value: attrValue}
+
+	return nil
+}
+
+// Marshal returns the ssrc attribute, "ssrc:" followed by the value.
+func (s SSRCAttribute) Marshal() string {
+	return AttrKeySSRC + ":" + s.value()
+}
+
+func (s SSRCAttribute) String() string {
+	return s.Marshal()
+}
+
+func (s SSRCAttribute) value() string {
+	value := strconv.FormatUint(uint64(s.SSRC), 10) + " " + s.Attribute
+	if s.Value != "" {
+		value += ":" + s.Value
+	}
+
+	return value
+}
+
+// SSRCGroup represents a ssrc-group attribute.
+// https://datatracker.ietf.org/doc/html/rfc5576#section-4.2
+type SSRCGroup struct {
+	// Semantics is a token like SemanticTokenFlowIdentification or
+	// SemanticTokenSimulcast.
+	Semantics string
+	SSRCs     []uint32
+}
+
+// Unmarshal parses a ssrc-group attribute. The value may be prefixed with
+// "a=ssrc-group:" or "ssrc-group:".
+func (g *SSRCGroup) Unmarshal(raw string) error {
+	value := strings.TrimPrefix(strings.TrimPrefix(raw, "a="), AttrKeySSRCGroup+":")
+	fields := strings.Fields(value)
+	if len(fields) < 2 || !isToken(fields[0]) {
+		return fmt.Errorf("%w `%v`", ErrInvalidSSRC, raw)
+	}
+
+	group := SSRCGroup{Semantics: fields[0]}
+	for _, field := range fields[1:] {
+		ssrc, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%w: ssrc `%v`", ErrInvalidSSRC, field)
+		}
+		group.SSRCs = append(group.SSRCs, uint32(ssrc))
+	}
+
+	*g = group
+
+	return nil
+}
+
+// Marshal returns the ssrc-group attribute, "ssrc-group:" followed by the
+// value.
+func (g SSRCGroup) Marshal() string {
+	return AttrKeySSRCGroup + ":" + g.value()
+}
+
+func (g SSRCGroup) String() string {
+	return g.Marshal()
+}
+
+func (g SSRCGroup) value() string {
+	value := g.Semantics
+	for _, ssrc := range g.SSRCs {
+		value += " " + strconv.FormatUint(uint64(ssrc), 10)
+	}
+
+	return value
+}
+
+// SSRCInfo collects the source attributes of a synchronization source.
+type SSRCInfo struct {
+	SSRC  uint32
+	CNAME string
+
+	// StreamID and TrackID are the values of the msid source attribute.
+	// StreamID is empty for a track without a stream, "-" in SDP.
+	StreamID string
+	TrackID  string
+
+	// MSLabel and Label are legacy source attributes that carried the
+	// stream and track IDs before msid. Builders only write them if set.
+	MSLabel string
+	Label   string
+
+	// Attributes holds all other source attributes in order.
+	Attributes []SSRCAttribute
+}
+
+// SSRCs parses the ssrc attributes of the media description and returns
+// them by SSRC.
+func (d *MediaDescription) SSRCs() (map[uint32]*SSRCInfo, error) {
+	ssrcs := map[uint32]*SSRCInfo{}
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeySSRC {
+			continue
+		}
+
+		var attr SSRCAttribute
+		if err := attr.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+
+		info, ok := ssrcs[attr.SSRC]
+		if !ok {
+			info = &SSRCInfo{SSRC: attr.SSRC}
+			ssrcs[attr.SSRC] = info
+		}
+
+		switch attr.Attribute {
+		case "cname":
+			info.CNAME = attr.Value
+		case "msid":
+			info.StreamID, info.TrackID, _ = strings.Cut(attr.Value, " ")
+			if info.StreamID == msidNoStream {
+				info.StreamID = ""
+			}
+		case "mslabel":
+			info.MSLabel = attr.Value
+		case "label":
+			info.Label = attr.Value
+		default:
+			info.Attributes = append(info.Attributes, attr)
+		}
+	}
+
+	return ssrcs, nil
+}
+
+// SSRCGroups parses the ssrc-group attributes of the media description.
+func (d *MediaDescription) SSRCGroups() ([]SSRCGroup, error) {
+	var groups []SSRCGroup
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeySSRCGroup {
+			continue
+		}
+
+		var group SSRCGroup
+		if err := group.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// WithSSRC adds the source attributes of a synchronization source to the
+// media description: cname and msid, the legacy mslabel and label only if
+// they are set, and the other attributes.
+func (d *MediaDescription) WithSSRC(info SSRCInfo) *MediaDescription {
+	add := func(attribute, value string) {
+		if value != "" {
+			d.WithSSRCAttribute(SSRCAttribute{SSRC: info.SSRC, Attribute: attribute, Value: value})
+		}
+	}
+
+	add("cname", info.CNAME)
+	if info.StreamID != "" || info.TrackID != "" {
+		add("msid", MSID{StreamID: info.StreamID, TrackID: info.TrackID}.value())
+	}
+	add("mslabel", info.MSLabel)
+	add("label", info.Label)
+	for _, attr := range info.Attributes {
+		attr.SSRC = info.SSRC
+		d.WithSSRCAttribute(attr)
+	}
+
+	return d
+}
+
+// WithSSRCAttribute adds a ssrc attribute to the media description.
+func (d *MediaDescription) WithSSRCAttribute(attr SSRCAttribute) *MediaDescription {
+	return d.WithValueAttribute(AttrKeySSRC, attr.value())
+}
+
+// WithSSRCGroup adds a ssrc-group attribute to the media description.
+func (d *MediaDescription) WithSSRCGroup(group SSRCGroup) *MediaDescription {
+	return d.WithValueAttribute(AttrKeySSRCGroup, group.value())
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSRCAttributeUnmarshal(t *testing.T) {
+	for raw, expected := range map[string]SSRCAttribute{
+		"a=ssrc:1234567890 cname:test-cname": {SSRC: 1234567890, Attribute: "cname", Value: "test-cname"},
+		"ssrc:1 msid:stream track":           {SSRC: 1, Attribute: "msid", Value: "stream track"},
+		"4294967295 x-flag":                  {SSRC: 4294967295, Attribute: "x-flag"},
+	} {
+		var attr SSRCAttribute
+		if assert.NoError(t, attr.Unmarshal(raw), raw) {
+			assert.Equal(t, expected, attr, raw)
+		}
+	}
+
+	attr := SSRCAttribute{SSRC: 1, Attribute: "msid", Value: "stream track"}
+	assert.Equal(t, "ssrc:1 msid:stream track", attr.Marshal())
+
+	for _, raw := range []string{"", "ssrc:1", "ssrc:1 ", "ssrc:x cname:a", "ssrc:4294967296 cname:a", "ssrc:1 (c):a"} {
+		assert.ErrorIs(t, attr.Unmarshal(raw), ErrInvalidSSRC, raw)
+	}
+}
+
+func TestSSRCGroupUnmarshal(t *testing.T) {
+	var group SSRCGroup
+	assert.NoError(t, group.Unmarshal("a=ssrc-group:FID 1 2"))
+	assert.Equal(t, SSRCGroup{Semantics: SemanticTokenFlowIdentification, SSRCs: []uint32{1, 2}}, group)
+	assert.Equal(t, "ssrc-group:FID 1 2", group.Marshal())
+
+	assert.NoError(t, group.Unmarshal("FEC-FR 3"))
+	assert.Equal(t, SSRCGroup{Semantics: SemanticTokenForwardErrorCorrectionFramework, SSRCs: []uint32{3}}, group)
+
+	for _, raw := range []string{"", "ssrc-group:SIM", "ssrc-group:SIM 1 x", "ssrc-group:S(M) 1"} {
+		assert.ErrorIs(t, group.Unmarshal(raw), ErrInvalidSSRC, raw)
+	}
+}
+
+func TestMediaDescriptionSSRCs(t *testing.T) {
+	md := NewJSEPMediaDescription("video", nil).
+		WithMediaSource(1, "cname", "stream", "track").
+		WithSSRC(SSRCInfo{
+			SSRC: 2, CNAME: "cname", StreamID: "stream", TrackID: "track",
+			Attributes: []SSRCAttribute{{Attribute: "x-flag"}},
+		}).
+		WithSSRCGroup(SSRCGroup{Semantics: SemanticTokenFlowIdentification, SSRCs: []uint32{1, 2}})
+
+	assert.Equal(t, []Attribute{
+		{Key: "ssrc", Value: "2 cname:cname"},
+		{Key: "ssrc", Value: "2 msid:stream track"},
+		{Key: "ssrc", Value: "2 x-flag"},
+		{Key: "ssrc-group", Value: "FID 1 2"},
+	}, md.Attributes[4:])
+
+	ssrcs, err := md.SSRCs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]*SSRCInfo{
+		1: {SSRC: 1, CNAME: "cname", StreamID: "stream", TrackID: "track", MSLabel: "stream", Label: "track"},
+		2: {
+			SSRC: 2, CNAME: "cname", StreamID: "stream", TrackID: "track",
+			Attributes: []SSRCAttribute{{SSRC: 2, Attribute: "x-flag"}},
+		},
+	}, ssrcs)
+
+	groups, err := md.SSRCGroups()
+	assert.NoError(t, err)
+	assert.Equal(t, []SSRCGroup{{Semantics: SemanticTokenFlowIdentification, SSRCs: []uint32{1, 2}}}, groups)
+
+	// Legacy attributes are only written when requested.
+	legacy := NewJSEPMediaDescription("video", nil).WithSSRC(*ssrcs[1])
+	assert.Len(t, legacy.Attributes, 4)
+
+	// A track without a stream round-trips through "-".
+	noStream := NewJSEPMediaDescription("audio", nil).WithSSRC(SSRCInfo{SSRC: 3, TrackID: "track"})
+	assert.Equal(t, []Attribute{{Key: "ssrc", Value: "3 msid:- track"}}, noStream.Attributes)
+	ssrcs, err = noStream.SSRCs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]*SSRCInfo{3: {SSRC: 3, TrackID: "track"}}, ssrcs)
+
+	md.WithValueAttribute("ssrc", "bogus")
+	_, err = md.SSRCs()
+	assert.ErrorIs(t, err, ErrInvalidSSRC)
+	md.WithValueAttribute("ssrc-group", "bogus")
+	_, err = md.SSRCGroups()
+	assert.ErrorIs(t, err, ErrInvalidSSRC)
+}
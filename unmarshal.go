@@ -13,11 +13,7 @@ import (
 )
 
 var (
-	errSDPInvalidSyntax       = errors.New("sdp: invalid syntax")
-	errSDPInvalidNumericValue = errors.New("sdp: invalid numeric value")
-	errSDPInvalidValue        = errors.New("sdp: invalid value")
-	errSDPInvalidPortValue    = errors.New("sdp: invalid port value")
-	errSDPCacheInvalid        = errors.New("sdp: invalid cache")
+	errSDPCacheInvalid = errors.New("sdp: invalid cache")
 
 	//nolint: gochecknoglobals
 	unmarshalCachePool = sync.Pool{
@@ -29,6 +25,7 @@ var (
 
 // UnmarshalString is the primary function that deserializes the session description
 // message and stores it inside of a structured SessionDescription object.
+// Parsing failures are reported as a *ParseError.
 //
 // The States Transition Table describes the computation flow between functions
 // (namely s1, s2, s3, ...) for a parsing procedure that complies with the
@@ -109,35 +106,101 @@ var (
 // |   s16  |    |    14 |    |     |    |  15 |   |    | 12 |   |   |     |   |   |    |   |    |
 // +--------+----+-------+----+-----+----+-----+---+----+----+---+---+-----+---+---+----+---+----+ .
 func (s *SessionDescription) UnmarshalString(value string) error {
+	_, err := UnmarshalOptions{}.UnmarshalString(value, s)
+
+	return err
+}
+
+// Unmarshal converts the value into a []byte and then calls UnmarshalString.
+// Callers should use the more performant UnmarshalString.
+func (s *SessionDescription) Unmarshal(value []byte) error {
+	return s.UnmarshalString(string(value))
+}
+
+// UnmarshalOptions configures how a session description is parsed. The zero
+// value parses exactly like SessionDescription.UnmarshalString.
+type UnmarshalOptions struct {
+	// Lenient makes the parser carry on past problems that real-world
+	// endpoints commonly produce: lines out of order, unknown line types,
+	// lines that are not of the form <type>=<value>, repeated "v=", "o=" or
+	// "s=" lines, a missing "t=" line and trailing whitespace. Each problem
+	// is reported as a ParseWarning. Malformed values are still errors.
+	Lenient bool
+
+	// Lossless keeps the original text of every line, so that Marshal
+	// reproduces the input byte for byte, including line endings, spacing
+	// and lines skipped in lenient mode. After the description is modified
+	// only the affected lines are rendered again: changed lines are replaced
+	// in place, removed lines are dropped and added lines are inserted after
+	// the closest line that precedes them in canonical order.
+	Lossless bool
+
+	// Limits bounds the size of the description. The zero value applies
+	// the defaults described in UnmarshalLimits.
+	Limits UnmarshalLimits
+}
+
+// UnmarshalString deserializes value into s according to the options. The
+// returned warnings are only collected in lenient mode.
+func (o UnmarshalOptions) UnmarshalString(value string, s *SessionDescription) ([]ParseWarning, error) {
 	var ok bool
 	lex := new(lexer)
 	if lex.cache, ok = unmarshalCachePool.Get().(*unmarshalCache); !ok {
-		return errSDPCacheInvalid
+		return nil, errSDPCacheInvalid
 	}
-	defer unmarshalCachePool.Put(lex.cache)
+	defer func() {
+		if !lex.cache.oversized() {
+			unmarshalCachePool.Put(lex.cache)
+		}
+	}()
 
 	lex.cache.reset()
+	// Original lines of an earlier lossless parse no longer match.
+	s.raw = nil
+	for _, md := range s.MediaDescriptions {
+		md.raw = nil
+	}
 	lex.desc = s
 	lex.value = value
+	lex.lenient = o.Lenient
+	lex.lossless = o.Lossless
+	lex.limits = o.Limits.withDefaults()
+
+	if err := checkLimit(len(value), lex.limits.MaxSize, "bytes"); err != nil {
+		return nil, lex.parseError(err)
+	}
 
 	for state := s1; state != nil; {
 		var err error
+		lex.state = state
 		state, err = state(lex)
 		if err != nil {
-			return err
+			return lex.warnings, lex.parseError(err)
+		}
+	}
+
+	if lex.lenient {
+		if err := lex.checkHeader(); err != nil {
+			return lex.warnings, lex.parseError(err)
+		}
+		if !lex.hasSeen('t') {
+			lex.warnMissingTiming()
 		}
 	}
 
 	s.Attributes = lex.cache.cloneSessionAttributes()
 	populateMediaAttributes(lex.cache, lex.desc)
 
-	return nil
+	if lex.lossless {
+		lex.attachRawLines()
+	}
+
+	return lex.warnings, nil
 }
 
-// Unmarshal converts the value into a []byte and then calls UnmarshalString.
-// Callers should use the more performant UnmarshalString.
-func (s *SessionDescription) Unmarshal(value []byte) error {
-	return s.UnmarshalString(string(value))
+// Unmarshal converts the value into a string and then calls UnmarshalString.
+func (o UnmarshalOptions) Unmarshal(value []byte, s *SessionDescription) ([]ParseWarning, error) {
+	return o.UnmarshalString(string(value), s)
 }
 
 func s1(l *lexer) (stateFn, error) {
@@ -426,7 +489,7 @@ func unmarshalProtocolVersion(l *lexer) (stateFn, error) {
 	// As off the latest draft of the rfc this value is required to be 0.
 	// https://tools.ietf.org/html/draft-ietf-rtcweb-jsep-24#section-5.8.1
 	if version != 0 {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, version)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, version)
 	}
 
 	if err := l.nextLine(); err != nil {
@@ -462,7 +525,7 @@ func unmarshalOrigin(lex *lexer) (stateFn, error) {
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.6
 	if !anyOf(lex.desc.Origin.NetworkType, "IN") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, lex.desc.Origin.NetworkType)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, lex.desc.Origin.NetworkType)
 	}
 
 	// Handle potentially missing AddressType field
@@ -474,7 +537,7 @@ func unmarshalOrigin(lex *lexer) (stateFn, error) {
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.7
 	if !anyOf(lex.desc.Origin.AddressType, "IP4", "IP6") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, lex.desc.Origin.AddressType)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, lex.desc.Origin.AddressType)
 	}
 
 	// Handle potentially missing UnicastAddress field
@@ -616,7 +679,7 @@ func (l *lexer) unmarshalConnectionInformation() (*ConnectionInformation, error)
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.6
 	if !anyOf(connInfo.NetworkType, "IN") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, connInfo.NetworkType)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, connInfo.NetworkType)
 	}
 
 	connInfo.AddressType, err = l.readField()
@@ -627,7 +690,7 @@ func (l *lexer) unmarshalConnectionInformation() (*ConnectionInformation, error)
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.7
 	if !anyOf(connInfo.AddressType, "IP4", "IP6") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, connInfo.AddressType)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, connInfo.AddressType)
 	}
 
 	address, err := l.readField()
@@ -655,7 +718,10 @@ func unmarshalSessionBandwidth(l *lexer) (stateFn, error) {
 
 	bandwidth, err := unmarshalBandwidth(value)
 	if err != nil {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidValue, value)
+		return nil, fmt.Errorf("%w `b=%v`", ErrInvalidValue, value)
+	}
+	if err = checkLimit(len(l.desc.Bandwidth)+1, l.limits.MaxBandwidths, "bandwidth lines"); err != nil {
+		return nil, err
 	}
 	l.desc.Bandwidth = append(l.desc.Bandwidth, *bandwidth)
 
@@ -665,7 +731,7 @@ func unmarshalSessionBandwidth(l *lexer) (stateFn, error) {
 func unmarshalBandwidth(value string) (*Bandwidth, error) {
 	parts := strings.Split(value, ":")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidValue, parts)
+		return nil, fmt.Errorf("%w `b=%v`", ErrInvalidValue, parts)
 	}
 
 	experimental := strings.HasPrefix(parts[0], "X-")
@@ -676,12 +742,12 @@ func unmarshalBandwidth(value string) (*Bandwidth, error) {
 		// https://tools.ietf.org/html/rfc4566#section-5.8
 		// https://tools.ietf.org/html/rfc3890#section-6.2
 		// https://tools.ietf.org/html/rfc3556#section-2
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, parts[0])
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, parts[0])
 	}
 
 	bandwidth, err := strconv.ParseUint(parts[1], 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidNumericValue, parts[1])
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidNumericValue, parts[1])
 	}
 
 	return &Bandwidth{
@@ -709,6 +775,10 @@ func unmarshalTiming(lex *lexer) (stateFn, error) {
 		return nil, err
 	}
 
+	err = checkLimit(len(lex.desc.TimeDescriptions)+1, lex.limits.MaxTimeDescriptions, "time descriptions")
+	if err != nil {
+		return nil, err
+	}
 	lex.desc.TimeDescriptions = append(lex.desc.TimeDescriptions, td)
 
 	return s9, nil
@@ -727,7 +797,7 @@ func unmarshalRepeatTimes(lex *lexer) (stateFn, error) {
 
 	newRepeatTime.Interval, err = parseTimeUnits(field)
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, field)
 	}
 
 	field, err = lex.readField()
@@ -737,7 +807,7 @@ func unmarshalRepeatTimes(lex *lexer) (stateFn, error) {
 
 	newRepeatTime.Duration, err = parseTimeUnits(field)
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, field)
 	}
 
 	for {
@@ -750,7 +820,7 @@ func unmarshalRepeatTimes(lex *lexer) (stateFn, error) {
 		}
 		offset, err := parseTimeUnits(field)
 		if err != nil {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+			return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, field)
 		}
 		newRepeatTime.Offsets = append(newRepeatTime.Offsets, offset)
 	}
@@ -759,6 +829,9 @@ func unmarshalRepeatTimes(lex *lexer) (stateFn, error) {
 		return nil, err
 	}
 
+	if err = checkLimit(len(latestTimeDesc.RepeatTimes)+1, lex.limits.MaxRepeatTimes, "repeat times"); err != nil {
+		return nil, err
+	}
 	latestTimeDesc.RepeatTimes = append(latestTimeDesc.RepeatTimes, newRepeatTime)
 
 	return s9, nil
@@ -819,6 +892,11 @@ func unmarshalSessionAttribute(l *lexer) (stateFn, error) {
 		return nil, err
 	}
 
+	err = checkLimit(len(l.cache.sessionAttributes)+1, l.limits.MaxAttributes, "session attributes")
+	if err != nil {
+		return nil, err
+	}
+
 	i := strings.IndexRune(value, ':')
 	a := l.cache.getSessionAttribute()
 	if i > 0 {
@@ -844,7 +922,7 @@ func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-5.14
 	if !anyOf(field, "audio", "video", "text", "application", "message") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, field)
 	}
 	newMediaDesc.MediaName.Media = field
 
@@ -856,14 +934,14 @@ func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
 	parts := strings.Split(field, "/")
 	newMediaDesc.MediaName.Port.Value, err = parsePort(parts[0])
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidPortValue, parts[0])
+		return nil, fmt.Errorf("%w `%v`", ErrInvalidPortValue, parts[0])
 	}
 
 	if len(parts) > 1 {
 		var portRange int
 		portRange, err = strconv.Atoi(parts[1])
 		if err != nil {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, parts)
+			return nil, fmt.Errorf("%w `%v`", ErrInvalidValue, parts)
 		}
 		newMediaDesc.MediaName.Port.Range = &portRange
 	}
@@ -896,7 +974,7 @@ func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
 			"IX",
 			"MRCPv2",
 		) {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidNumericValue, field)
+			return nil, fmt.Errorf("%w `%v`", ErrInvalidNumericValue, field)
 		}
 		newMediaDesc.MediaName.Protos = append(newMediaDesc.MediaName.Protos, proto)
 	}
@@ -917,6 +995,10 @@ func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
 		return nil, err
 	}
 
+	err = checkLimit(len(lex.desc.MediaDescriptions)+1, lex.limits.MaxMediaDescriptions, "media descriptions")
+	if err != nil {
+		return nil, err
+	}
 	lex.desc.MediaDescriptions = append(lex.desc.MediaDescriptions, &newMediaDesc)
 
 	return s12, nil
@@ -955,7 +1037,10 @@ func unmarshalMediaBandwidth(l *lexer) (stateFn, error) {
 	latestMediaDesc := l.desc.MediaDescriptions[len(l.desc.MediaDescriptions)-1]
 	bandwidth, err := unmarshalBandwidth(value)
 	if err != nil {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidSyntax, value)
+		return nil, fmt.Errorf("%w `b=%v`", ErrInvalidSyntax, value)
+	}
+	if err = checkLimit(len(latestMediaDesc.Bandwidth)+1, l.limits.MaxBandwidths, "bandwidth lines"); err != nil {
+		return nil, err
 	}
 	latestMediaDesc.Bandwidth = append(latestMediaDesc.Bandwidth, *bandwidth)
 
@@ -981,6 +1066,11 @@ func unmarshalMediaAttribute(l *lexer) (stateFn, error) {
 		return nil, err
 	}
 
+	err = checkLimit(len(l.cache.mediaAttributes)+1, l.limits.MaxAttributes, "media attributes")
+	if err != nil {
+		return nil, err
+	}
+
 	i := strings.IndexRune(value, ':')
 	a := l.cache.getMediaAttribute()
 	if i > 0 {
@@ -995,7 +1085,7 @@ func unmarshalMediaAttribute(l *lexer) (stateFn, error) {
 
 func parseTimeUnits(value string) (num int64, err error) {
 	if len(value) == 0 {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrInvalidValue, value)
 	}
 	k := timeShorthand(value[len(value)-1])
 	if k > 0 {
@@ -1005,7 +1095,7 @@ func parseTimeUnits(value string) (num int64, err error) {
 		num, err = strconv.ParseInt(value, 10, 64)
 	}
 	if err != nil {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrInvalidValue, value)
 	}
 
 	return num * k, nil
@@ -1031,11 +1121,11 @@ func timeShorthand(b byte) int64 {
 func parsePort(value string) (int, error) {
 	port, err := strconv.Atoi(value)
 	if err != nil {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidPortValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrInvalidPortValue, value)
 	}
 
 	if port < 0 || port > 65535 {
-		return 0, fmt.Errorf("%w -- out of range `%v`", errSDPInvalidPortValue, port)
+		return 0, fmt.Errorf("%w -- out of range `%v`", ErrInvalidPortValue, port)
 	}
 
 	return port, nil
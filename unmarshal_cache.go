@@ -13,6 +13,11 @@ func (c *unmarshalCache) reset() {
 	c.mediaAttributes = c.mediaAttributes[:0]
 }
 
+// oversized reports whether the cache grew too large to be pooled.
+func (c *unmarshalCache) oversized() bool {
+	return cap(c.sessionAttributes) > defaultMaxAttributes || cap(c.mediaAttributes) > defaultMaxAttributes
+}
+
 func (c *unmarshalCache) getSessionAttribute() *Attribute {
 	c.sessionAttributes = append(c.sessionAttributes, Attribute{})
 
@@ -362,7 +362,7 @@ func TestUnmarshalRepeatTimes(t *testing.T) {
 	assert.Equal(t, RepeatTimesSDPExpected, string(actual))
 
 	err = sd.UnmarshalString(TimingSDP + "r=\r\n")
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrInvalidValue)
 }
 
 func TestUnmarshalTimeZones(t *testing.T) {
@@ -402,11 +402,11 @@ func TestUnmarshalPortRange(t *testing.T) {
 	}{
 		{
 			In:          SessionAttributesSDP + "m=video -1 RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrInvalidPortValue,
 		},
 		{
 			In:          SessionAttributesSDP + "m=video 65536 RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrInvalidPortValue,
 		},
 		{
 			In:          SessionAttributesSDP + "m=video 0 RTP/AVP 99\r\n",
@@ -418,7 +418,7 @@ func TestUnmarshalPortRange(t *testing.T) {
 		},
 		{
 			In:          SessionAttributesSDP + "m=video --- RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrInvalidPortValue,
 		},
 	} {
 		var sd SessionDescription
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -28,38 +27,9 @@ var (
 	errFieldMissing        = errors.New("field missing")
 )
 
-// ConnectionRole indicates which of the end points should initiate the connection establishment.
-type ConnectionRole int
-
-const (
-	// ConnectionRoleActive indicates the endpoint will initiate an outgoing connection.
-	ConnectionRoleActive ConnectionRole = iota + 1
-
-	// ConnectionRolePassive indicates the endpoint will accept an incoming connection.
-	ConnectionRolePassive
-
-	// ConnectionRoleActpass indicates the endpoint is willing to accept an incoming connection or
-	// to initiate an outgoing connection.
-	ConnectionRoleActpass
-
-	// ConnectionRoleHoldconn indicates the endpoint does not want the connection to be established for the time being.
-	ConnectionRoleHoldconn
-)
-
-func (t ConnectionRole) String() string {
-	switch t {
-	case ConnectionRoleActive:
-		return "active"
-	case ConnectionRolePassive:
-		return "passive"
-	case ConnectionRoleActpass:
-		return "actpass"
-	case ConnectionRoleHoldconn:
-		return "holdconn"
-	default:
-		return "Unknown"
-	}
-}
+// ErrAmbiguousPayloadType indicates a payload type that media descriptions
+// map to different codecs, so it cannot be resolved at session level.
+var ErrAmbiguousPayloadType = errors.New("sdp: payload type is ambiguous across media descriptions")
 
 func newSessionID() (uint64, error) {
 	// https://tools.ietf.org/html/draft-ietf-rtcweb-jsep-26#section-5.2.1
@@ -227,45 +197,56 @@ func mergeCodecs(codec Codec, codecs map[uint8]Codec) {
 	codecs[savedCodec.PayloadType] = savedCodec
 }
 
-func (s *SessionDescription) buildCodecMap() map[uint8]Codec { //nolint:cyclop
-	codecs := map[uint8]Codec{
-		// static codecs that do not require a rtpmap
-		0: {
-			PayloadType: 0,
-			Name:        "PCMU",
-			ClockRate:   8000,
-		},
-		8: {
-			PayloadType: 8,
-			Name:        "PCMA",
-			ClockRate:   8000,
-		},
+// staticCodecs are the static payload types that do not require a rtpmap.
+var staticCodecs = map[uint8]Codec{ //nolint:gochecknoglobals
+	0: {
+		PayloadType: 0,
+		Name:        "PCMU",
+		ClockRate:   8000,
+	},
+	8: {
+		PayloadType: 8,
+		Name:        "PCMA",
+		ClockRate:   8000,
+	},
+}
+
+// buildCodecMap collects the codecs of the media description. Wildcard
+// rtcp-fb attributes only apply to the codecs of the same section.
+func (d *MediaDescription) buildCodecMap() map[uint8]Codec { //nolint:cyclop
+	codecs := map[uint8]Codec{}
+	for _, format := range d.MediaName.Formats {
+		payloadType, err := strconv.ParseUint(format, 10, 8)
+		if err != nil {
+			continue
+		}
+		if codec, ok := staticCodecs[uint8(payloadType)]; ok {
+			codecs[codec.PayloadType] = codec
+		}
 	}
 
 	wildcardRTCPFeedback := []string{}
-	for _, m := range s.MediaDescriptions {
-		for _, a := range m.Attributes {
-			attr := a.String()
+	for _, a := range d.Attributes {
+		attr := a.String()
+		switch {
+		case strings.HasPrefix(attr, "rtpmap:"):
+			codec, err := parseRtpmap(attr)
+			if err == nil {
+				mergeCodecs(codec, codecs)
+			}
+		case strings.HasPrefix(attr, "fmtp:"):
+			codec, err := parseFmtp(attr)
+			if err == nil {
+				mergeCodecs(codec, codecs)
+			}
+		case strings.HasPrefix(attr, "rtcp-fb:"):
+			codec, isWildcard, err := parseRtcpFb(attr)
 			switch {
-			case strings.HasPrefix(attr, "rtpmap:"):
-				codec, err := parseRtpmap(attr)
-				if err == nil {
-					mergeCodecs(codec, codecs)
-				}
-			case strings.HasPrefix(attr, "fmtp:"):
-				codec, err := parseFmtp(attr)
-				if err == nil {
-					mergeCodecs(codec, codecs)
-				}
-			case strings.HasPrefix(attr, "rtcp-fb:"):
-				codec, isWildcard, err := parseRtcpFb(attr)
-				switch {
-				case err != nil:
-				case isWildcard:
-					wildcardRTCPFeedback = append(wildcardRTCPFeedback, codec.RTCPFeedback...)
-				default:
-					mergeCodecs(codec, codecs)
-				}
+			case err != nil:
+			case isWildcard:
+				wildcardRTCPFeedback = append(wildcardRTCPFeedback, codec.RTCPFeedback...)
+			default:
+				mergeCodecs(codec, codecs)
 			}
 		}
 	}
@@ -281,26 +262,93 @@ func (s *SessionDescription) buildCodecMap() map[uint8]Codec { //nolint:cyclop
 	return codecs
 }
 
-func equivalentFmtp(want, got string) bool {
-	wantSplit := strings.Split(want, ";")
-	gotSplit := strings.Split(got, ";")
+// Codecs returns the codecs of the media description in the order of the
+// formats of the "m=" line. Formats that are not payload types are skipped.
+func (d *MediaDescription) Codecs() []Codec {
+	codecs := d.buildCodecMap()
 
-	if len(wantSplit) != len(gotSplit) {
-		return false
+	result := make([]Codec, 0, len(d.MediaName.Formats))
+	for _, format := range d.MediaName.Formats {
+		payloadType, err := strconv.ParseUint(format, 10, 8)
+		if err != nil {
+			continue
+		}
+
+		codec, ok := codecs[uint8(payloadType)]
+		if !ok {
+			codec.PayloadType = uint8(payloadType)
+		}
+		result = append(result, codec)
+	}
+
+	return result
+}
+
+// GetCodecForPayloadType scans the MediaDescription for the given payload type and returns the codec.
+func (d *MediaDescription) GetCodecForPayloadType(payloadType uint8) (Codec, error) {
+	codec, ok := d.buildCodecMap()[payloadType]
+	if ok {
+		return codec, nil
+	}
+
+	return codec, errPayloadTypeNotFound
+}
+
+// GetPayloadTypeForCodec scans the MediaDescription for a codec that matches the provided codec
+// and returns its payload type. Codecs are tried in the order of the formats of the "m=" line, so the
+// most preferred match is returned. If the provided codec has format parameters, equivalent ones are
+// preferred over ones that are compatible by the rules of the codec, like H264 profile-level-id. Use
+// MatchCodecs for all matching codecs, ranked.
+func (d *MediaDescription) GetPayloadTypeForCodec(wanted Codec) (uint8, error) {
+	codecs := d.Codecs()
+	for _, codec := range codecs {
+		if codecsMatch(wanted, codec) {
+			return codec.PayloadType, nil
+		}
+	}
+	for _, codec := range codecs {
+		if codecsCompatible(wanted, codec) {
+			return codec.PayloadType, nil
+		}
 	}
 
-	sort.Strings(wantSplit)
-	sort.Strings(gotSplit)
+	return 0, errCodecNotFound
+}
 
-	for i, wantPart := range wantSplit {
-		wantPart = strings.TrimSpace(wantPart)
-		gotPart := strings.TrimSpace(gotSplit[i])
-		if gotPart != wantPart {
-			return false
+// sessionCodec looks up a payload type in every media description. A
+// payload type that maps to different codecs in different sections is
+// ambiguous.
+func (s *SessionDescription) sessionCodec(payloadType uint8) (Codec, bool, error) {
+	var (
+		result Codec
+		found  bool
+	)
+	for _, m := range s.MediaDescriptions {
+		codec, ok := m.buildCodecMap()[payloadType]
+		switch {
+		case !ok:
+		case !found:
+			result, found = codec, true
+		case !sameCodec(result, codec):
+			return result, true, fmt.Errorf("%w: %d is %s/%d and %s/%d", ErrAmbiguousPayloadType,
+				payloadType, result.Name, result.ClockRate, codec.Name, codec.ClockRate)
 		}
 	}
 
-	return true
+	return result, found, nil
+}
+
+// sameCodec reports whether two codecs are the same, regardless of their
+// RTCP feedback.
+func sameCodec(a, b Codec) bool {
+	return strings.EqualFold(a.Name, b.Name) &&
+		a.ClockRate == b.ClockRate &&
+		a.EncodingParameters == b.EncodingParameters &&
+		equivalentFmtp(a.Fmtp, b.Fmtp)
+}
+
+func equivalentFmtp(want, got string) bool {
+	return ParseFmtpParams(want).Equivalent(ParseFmtpParams(got))
 }
 
 func codecsMatch(wanted, got Codec) bool {
@@ -320,11 +368,33 @@ func codecsMatch(wanted, got Codec) bool {
 	return true
 }
 
+// codecsCompatible is like codecsMatch, but accepts format parameters that
+// differ if the rules of the codec allow to negotiate them, like H264
+// profile-level-ids with the same profile and different levels.
+func codecsCompatible(wanted, got Codec) bool {
+	if codecsMatch(wanted, got) {
+		return true
+	}
+
+	wantedParams, gotParams := ParseFmtpParams(wanted.Fmtp), ParseFmtpParams(got.Fmtp)
+	wanted.Fmtp, got.Fmtp = "", ""
+	if !codecsMatch(wanted, got) {
+		return false
+	}
+	compatible, _ := fmtpCompatible(got.Name, wantedParams, gotParams)
+
+	return compatible
+}
+
 // GetCodecForPayloadType scans the SessionDescription for the given payload type and returns the codec.
+// The codec is taken from the first media description that uses the payload type. If media descriptions
+// map the payload type to different codecs, ErrAmbiguousPayloadType is returned; use
+// MediaDescription.GetCodecForPayloadType instead.
 func (s *SessionDescription) GetCodecForPayloadType(payloadType uint8) (Codec, error) {
-	codecs := s.buildCodecMap()
-
-	codec, ok := codecs[payloadType]
+	codec, ok, err := s.sessionCodec(payloadType)
+	if err != nil {
+		return Codec{}, err
+	}
 	if ok {
 		return codec, nil
 	}
@@ -332,12 +402,15 @@ func (s *SessionDescription) GetCodecForPayloadType(payloadType uint8) (Codec, e
 	return codec, errPayloadTypeNotFound
 }
 
+// GetCodecsForPayloadTypes scans the SessionDescription for the given payload types and returns
+// the codecs that are found, see GetCodecForPayloadType.
 func (s *SessionDescription) GetCodecsForPayloadTypes(payloadTypes []uint8) ([]Codec, error) {
-	codecs := s.buildCodecMap()
-
 	result := make([]Codec, 0, len(payloadTypes))
 	for _, payloadType := range payloadTypes {
-		codec, ok := codecs[payloadType]
+		codec, ok, err := s.sessionCodec(payloadType)
+		if err != nil {
+			return nil, err
+		}
 		if ok {
 			result = append(result, codec)
 		}
@@ -347,14 +420,20 @@ func (s *SessionDescription) GetCodecsForPayloadTypes(payloadTypes []uint8) ([]C
 }
 
 // GetPayloadTypeForCodec scans the SessionDescription for a codec that matches the provided codec
-// as closely as possible and returns its payload type.
+// as closely as possible and returns its payload type. Media descriptions are scanned in order, see
+// MediaDescription.GetPayloadTypeForCodec. Use MatchCodecs for all matching codecs, ranked.
 func (s *SessionDescription) GetPayloadTypeForCodec(wanted Codec) (uint8, error) {
-	codecs := s.buildCodecMap()
+	for _, m := range s.MediaDescriptions {
+		payloadType, err := m.GetPayloadTypeForCodec(wanted)
+		if err != nil {
+			continue
+		}
 
-	for payloadType, codec := range codecs {
-		if codecsMatch(wanted, codec) {
-			return payloadType, nil
+		if _, _, err := s.sessionCodec(payloadType); err != nil {
+			return 0, err
 		}
+
+		return payloadType, nil
 	}
 
 	return 0, errCodecNotFound
@@ -366,6 +445,17 @@ type lexer struct {
 	desc  *SessionDescription
 	cache *unmarshalCache
 	baseLexer
+
+	lenient  bool
+	state    stateFn
+	phase    linePhase
+	seen     uint32
+	warnings []ParseWarning
+
+	lossless bool
+	rawLines []rawLineRef
+
+	limits UnmarshalLimits
 }
 
 type keyToState func(key byte) stateFn
@@ -375,12 +465,54 @@ func (l *lexer) handleType(fn keyToState) (stateFn, error) {
 	if errors.Is(err, io.EOF) && key == 0 {
 		return nil, nil //nolint:nilnil
 	} else if err != nil {
+		if l.lenient && errors.Is(err, errTrailingField) {
+			// The line is kept as parsed, only its leftover is dropped.
+			l.warn(WarningMalformedLine, 0)
+			l.skipLine()
+
+			return l.state, nil
+		}
+
+		var syntaxErr syntaxError
+		if l.lenient && errors.As(err, &syntaxErr) {
+			l.recordLine(0)
+
+			return l.skipMalformedLine(), nil
+		}
+
+		return nil, err
+	}
+
+	l.recordLine(key)
+
+	if err := checkLimit(len(l.currentLine()), l.limits.MaxLineLength, "bytes in a line"); err != nil {
 		return nil, err
 	}
 
+	if l.lenient {
+		l.checkTrailingWhitespace(key)
+	}
+
 	if res := fn(key); res != nil {
+		l.accept(key)
+
 		return res, nil
 	}
 
+	if l.lenient {
+		return l.recoverLine(key), nil
+	}
+
 	return nil, l.syntaxError()
 }
+
+// Returns symbols until line end. In lenient mode trailing whitespace is
+// dropped.
+func (l *lexer) readLine() (string, error) {
+	line, err := l.baseLexer.readLine()
+	if err != nil || !l.lenient {
+		return line, err
+	}
+
+	return strings.TrimRight(line, " \t"), nil
+}
@@ -270,6 +270,92 @@ func TestGetCodecsForPayloadTypes(t *testing.T) {
 	}
 }
 
+func getTestAmbiguousSessionDescription() SessionDescription {
+	return SessionDescription{
+		MediaDescriptions: []*MediaDescription{
+			{
+				MediaName: MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96", "0"},
+				},
+				Attributes: []Attribute{
+					NewAttribute("rtpmap", "96 opus/48000/2"),
+					NewAttribute("fmtp", "96 minptime=10;useinbandfec=1"),
+				},
+			},
+			{
+				MediaName: MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97", "96", "98"},
+				},
+				Attributes: []Attribute{
+					NewAttribute("rtpmap", "96 VP8/90000"),
+					NewAttribute("rtpmap", "97 VP9/90000"),
+					NewAttribute("rtcp-fb", "* nack"),
+					NewAttribute("rtcp-fb", "96 goog-remb"),
+				},
+			},
+		},
+	}
+}
+
+func TestMediaDescriptionCodecs(t *testing.T) {
+	sd := getTestAmbiguousSessionDescription()
+
+	assert.Equal(t, []Codec{
+		{PayloadType: 96, Name: "opus", ClockRate: 48000, EncodingParameters: "2", Fmtp: "minptime=10;useinbandfec=1"},
+		{PayloadType: 0, Name: "PCMU", ClockRate: 8000},
+	}, sd.MediaDescriptions[0].Codecs())
+
+	assert.Equal(t, []Codec{
+		{PayloadType: 97, Name: "VP9", ClockRate: 90000, RTCPFeedback: []string{"nack"}},
+		{PayloadType: 96, Name: "VP8", ClockRate: 90000, RTCPFeedback: []string{"goog-remb", "nack"}},
+		{PayloadType: 98},
+	}, sd.MediaDescriptions[1].Codecs())
+
+	codec, err := sd.MediaDescriptions[1].GetCodecForPayloadType(96)
+	assert.NoError(t, err)
+	assert.Equal(t, "VP8", codec.Name)
+
+	_, err = sd.MediaDescriptions[1].GetCodecForPayloadType(0)
+	assert.ErrorIs(t, err, errPayloadTypeNotFound)
+
+	payloadType, err := sd.MediaDescriptions[0].GetPayloadTypeForCodec(Codec{Name: "opus"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(96), payloadType)
+
+	_, err = sd.MediaDescriptions[0].GetPayloadTypeForCodec(Codec{Name: "VP8"})
+	assert.ErrorIs(t, err, errCodecNotFound)
+}
+
+func TestAmbiguousPayloadType(t *testing.T) {
+	sd := getTestAmbiguousSessionDescription()
+
+	_, err := sd.GetCodecForPayloadType(96)
+	assert.ErrorIs(t, err, ErrAmbiguousPayloadType)
+
+	_, err = sd.GetCodecsForPayloadTypes([]uint8{97, 96})
+	assert.ErrorIs(t, err, ErrAmbiguousPayloadType)
+
+	_, err = sd.GetPayloadTypeForCodec(Codec{Name: "VP8"})
+	assert.ErrorIs(t, err, ErrAmbiguousPayloadType)
+
+	codec, err := sd.GetCodecForPayloadType(97)
+	assert.NoError(t, err)
+	assert.Equal(t, Codec{PayloadType: 97, Name: "VP9", ClockRate: 90000, RTCPFeedback: []string{"nack"}}, codec)
+
+	// Wildcard feedback of the video section does not apply to audio codecs.
+	codec, err = sd.GetCodecForPayloadType(0)
+	assert.NoError(t, err)
+	assert.Empty(t, codec.RTCPFeedback)
+
+	payloadType, err := sd.GetPayloadTypeForCodec(Codec{Name: "VP9"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(97), payloadType)
+}
+
 func TestNewSessionID(t *testing.T) {
 	minVal := uint64(0x7FFFFFFFFFFFFFFF)
 	maxVal := uint64(0)
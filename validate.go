@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrMissingField indicates that a required field is empty.
+	ErrMissingField = errors.New("sdp: missing required field")
+
+	// ErrInvalidToken indicates a field that must be a token but contains
+	// other characters.
+	ErrInvalidToken = errors.New("sdp: invalid token")
+
+	// ErrInvalidAddress indicates an address that does not match its
+	// address type.
+	ErrInvalidAddress = errors.New("sdp: invalid address")
+)
+
+// ValidationError describes a single violation found by Validate.
+type ValidationError struct {
+	// Field is the path of the offending field, for example
+	// "MediaName.Port" or "Attributes[2].Key". ValidateJSEP reports the
+	// attribute key instead, for example "mid".
+	Field string
+
+	// MediaIndex is the index of the media description the field belongs
+	// to, -1 for session level fields.
+	MediaIndex int
+
+	// Err is one of ErrMissingField, ErrInvalidToken, ErrInvalidAddress,
+	// ErrInvalidValue or ErrInvalidPortValue, or one of the ErrJSEP errors
+	// for ValidateJSEP, wrapped with details.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if e.MediaIndex >= 0 {
+		return fmt.Sprintf("%v (media %d: %s)", e.Err, e.MediaIndex, e.Field)
+	}
+
+	return fmt.Sprintf("%v (%s)", e.Err, e.Field)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors lists all violations found by Validate.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Validate checks the session description against the grammar of RFC 8866
+// before it is marshaled. It returns nil if the description is valid,
+// otherwise ValidationErrors listing every violation.
+func (s *SessionDescription) Validate() error {
+	v := validator{media: -1}
+	v.validateSession(s)
+
+	for i, md := range s.MediaDescriptions {
+		v.media = i
+		if md == nil {
+			v.add("", fmt.Errorf("%w: nil media description", ErrMissingField))
+
+			continue
+		}
+		v.validateMedia(md)
+		if s.ConnectionInformation == nil && md.ConnectionInformation == nil {
+			v.add("ConnectionInformation", fmt.Errorf("%w: no c= at session or media level", ErrMissingField))
+		}
+	}
+
+	return v.result()
+}
+
+// Validate checks the media description against the grammar of RFC 8866.
+// It returns nil if the description is valid, otherwise ValidationErrors
+// listing every violation with a MediaIndex of 0.
+func (d *MediaDescription) Validate() error {
+	var v validator
+	v.validateMedia(d)
+
+	return v.result()
+}
+
+type validator struct {
+	media int
+	errs  ValidationErrors
+}
+
+func (v *validator) result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+func (v *validator) add(field string, err error) {
+	v.errs = append(v.errs, &ValidationError{Field: field, MediaIndex: v.media, Err: err})
+}
+
+func (v *validator) validateSession(s *SessionDescription) {
+	if s.Version != 0 {
+		v.add("Version", fmt.Errorf("%w `v=%d`", ErrInvalidValue, s.Version))
+	}
+
+	v.nonWSString("Origin.Username", s.Origin.Username)
+	v.networkType("Origin.NetworkType", s.Origin.NetworkType)
+	v.addressType("Origin.AddressType", s.Origin.AddressType)
+	v.nonWSString("Origin.UnicastAddress", s.Origin.UnicastAddress)
+	if s.Origin.UnicastAddress != "" {
+		v.host("Origin.UnicastAddress", s.Origin.AddressType, s.Origin.UnicastAddress)
+	}
+
+	v.text("SessionName", string(s.SessionName))
+	if s.SessionInformation != nil {
+		v.text("SessionInformation", string(*s.SessionInformation))
+	}
+	if s.URI != nil {
+		v.text("URI", s.URI.String())
+	}
+	if s.EmailAddress != nil {
+		v.text("EmailAddress", string(*s.EmailAddress))
+	}
+	if s.PhoneNumber != nil {
+		v.text("PhoneNumber", string(*s.PhoneNumber))
+	}
+	if s.ConnectionInformation != nil {
+		v.connectionInformation("ConnectionInformation", s.ConnectionInformation)
+	}
+	v.bandwidths(s.Bandwidth)
+
+	if len(s.TimeDescriptions) == 0 {
+		v.add("TimeDescriptions", fmt.Errorf("%w: no t= line", ErrMissingField))
+	}
+	for i, td := range s.TimeDescriptions {
+		v.timeDescription(fmt.Sprintf("TimeDescriptions[%d]", i), td)
+	}
+
+	if s.EncryptionKey != nil {
+		v.text("EncryptionKey", string(*s.EncryptionKey))
+	}
+	v.attributes(s.Attributes)
+}
+
+func (v *validator) validateMedia(d *MediaDescription) {
+	v.token("MediaName.Media", d.MediaName.Media)
+
+	if port := d.MediaName.Port; port.Value < 0 || port.Value > 65535 {
+		v.add("MediaName.Port", fmt.Errorf("%w `%d`", ErrInvalidPortValue, port.Value))
+	}
+	if r := d.MediaName.Port.Range; r != nil && *r < 1 {
+		v.add("MediaName.Port.Range", fmt.Errorf("%w `%d`", ErrInvalidValue, *r))
+	}
+
+	if len(d.MediaName.Protos) == 0 {
+		v.add("MediaName.Protos", fmt.Errorf("%w: no transport protocol", ErrMissingField))
+	}
+	for i, proto := range d.MediaName.Protos {
+		v.token(fmt.Sprintf("MediaName.Protos[%d]", i), proto)
+	}
+
+	if len(d.MediaName.Formats) == 0 {
+		v.add("MediaName.Formats", fmt.Errorf("%w: no media format", ErrMissingField))
+	}
+	for i, format := range d.MediaName.Formats {
+		v.token(fmt.Sprintf("MediaName.Formats[%d]", i), format)
+	}
+
+	if d.MediaTitle != nil {
+		v.text("MediaTitle", string(*d.MediaTitle))
+	}
+	if d.ConnectionInformation != nil {
+		v.connectionInformation("ConnectionInformation", d.ConnectionInformation)
+	}
+	v.bandwidths(d.Bandwidth)
+	if d.EncryptionKey != nil {
+		v.text("EncryptionKey", string(*d.EncryptionKey))
+	}
+	v.attributes(d.Attributes)
+}
+
+func (v *validator) connectionInformation(field string, c *ConnectionInformation) {
+	v.networkType(field+".NetworkType", c.NetworkType)
+	v.addressType(field+".AddressType", c.AddressType)
+
+	if c.Address == nil || c.Address.Address == "" {
+		v.add(field+".Address", fmt.Errorf("%w: no connection address", ErrMissingField))
+
+		return
+	}
+
+	// The parser keeps the TTL and number of addresses in Address.
+	parts := strings.Split(c.Address.Address, "/")
+	host := parts[0]
+	ttl, addresses := c.Address.TTL, c.Address.Range
+	for _, part := range parts[1:] {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			v.add(field+".Address", fmt.Errorf("%w `%v`", ErrInvalidAddress, c.Address.Address))
+
+			return
+		}
+		if ttl == nil && c.AddressType == "IP4" {
+			ttl = &n
+		} else {
+			addresses = &n
+		}
+	}
+
+	v.host(field+".Address", c.AddressType, host)
+
+	ip := net.ParseIP(host)
+	multicast := ip != nil && ip.IsMulticast()
+	switch {
+	case c.AddressType == "IP4" && multicast && ttl == nil:
+		v.add(field+".Address.TTL", fmt.Errorf("%w: no TTL for IP4 multicast address", ErrMissingField))
+	case ttl != nil && (c.AddressType != "IP4" || !multicast):
+		v.add(field+".Address.TTL", fmt.Errorf("%w: TTL only applies to IP4 multicast addresses", ErrInvalidAddress))
+	case ttl != nil && (*ttl < 0 || *ttl > 255):
+		v.add(field+".Address.TTL", fmt.Errorf("%w `%d`", ErrInvalidValue, *ttl))
+	}
+
+	switch {
+	case addresses != nil && !multicast:
+		v.add(field+".Address.Range", fmt.Errorf("%w: range only applies to multicast addresses", ErrInvalidAddress))
+	case addresses != nil && *addresses < 1:
+		v.add(field+".Address.Range", fmt.Errorf("%w `%d`", ErrInvalidValue, *addresses))
+	}
+}
+
+func (v *validator) bandwidths(bandwidths []Bandwidth) {
+	for i, b := range bandwidths {
+		v.token(fmt.Sprintf("Bandwidth[%d].Type", i), b.Type)
+	}
+}
+
+func (v *validator) timeDescription(field string, td TimeDescription) {
+	if td.Timing.StopTime != 0 && td.Timing.StopTime < td.Timing.StartTime {
+		v.add(field+".Timing", fmt.Errorf("%w: stop time before start time `%v`", ErrInvalidValue, td.Timing))
+	}
+
+	for i, r := range td.RepeatTimes {
+		repeatField := fmt.Sprintf("%s.RepeatTimes[%d]", field, i)
+		if r.Interval <= 0 {
+			v.add(repeatField+".Interval", fmt.Errorf("%w `%d`", ErrInvalidValue, r.Interval))
+		}
+		if r.Duration < 0 {
+			v.add(repeatField+".Duration", fmt.Errorf("%w `%d`", ErrInvalidValue, r.Duration))
+		}
+		if len(r.Offsets) == 0 {
+			v.add(repeatField+".Offsets", fmt.Errorf("%w: no offset", ErrMissingField))
+		}
+		for j, offset := range r.Offsets {
+			if offset < 0 {
+				v.add(fmt.Sprintf("%s.Offsets[%d]", repeatField, j), fmt.Errorf("%w `%d`", ErrInvalidValue, offset))
+			}
+		}
+	}
+}
+
+func (v *validator) attributes(attributes []Attribute) {
+	for i, a := range attributes {
+		v.token(fmt.Sprintf("Attributes[%d].Key", i), a.Key)
+		if a.Value != "" {
+			v.text(fmt.Sprintf("Attributes[%d].Value", i), a.Value)
+		}
+	}
+}
+
+func (v *validator) networkType(field, value string) {
+	// Set according to currently registered with IANA
+	// https://tools.ietf.org/html/rfc4566#section-8.2.6
+	if !anyOf(value, "IN") {
+		v.add(field, fmt.Errorf("%w `%v`", ErrInvalidValue, value))
+	}
+}
+
+func (v *validator) addressType(field, value string) {
+	// Set according to currently registered with IANA
+	// https://tools.ietf.org/html/rfc4566#section-8.2.7
+	if !anyOf(value, "IP4", "IP6") {
+		v.add(field, fmt.Errorf("%w `%v`", ErrInvalidValue, value))
+	}
+}
+
+// host checks that value is an address of the given type or a domain name.
+func (v *validator) host(field, addressType, value string) {
+	if ip := net.ParseIP(value); ip != nil {
+		if strings.Contains(value, ":") != (addressType == "IP6") {
+			v.add(field, fmt.Errorf("%w: `%v` is not %s", ErrInvalidAddress, value, addressType))
+		}
+
+		return
+	}
+
+	if !isDomainName(value) {
+		v.add(field, fmt.Errorf("%w `%v`", ErrInvalidAddress, value))
+	}
+}
+
+func (v *validator) token(field, value string) {
+	if value == "" {
+		v.add(field, ErrMissingField)
+
+		return
+	}
+
+	if !isToken(value) {
+		v.add(field, fmt.Errorf("%w `%v`", ErrInvalidToken, value))
+	}
+}
+
+func (v *validator) nonWSString(field, value string) {
+	if value == "" {
+		v.add(field, ErrMissingField)
+
+		return
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] <= ' ' || value[i] == 0x7f {
+			v.add(field, fmt.Errorf("%w `%v`", ErrInvalidValue, value))
+
+			return
+		}
+	}
+}
+
+// text checks for a non-empty byte-string, which excludes NUL, CR and LF.
+func (v *validator) text(field, value string) {
+	if value == "" {
+		v.add(field, ErrMissingField)
+
+		return
+	}
+
+	if strings.ContainsAny(value, "\x00\r\n") {
+		v.add(field, fmt.Errorf("%w: %q", ErrInvalidValue, value))
+	}
+}
+
+// isToken reports whether value is a token of RFC 8866.
+func isToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if !isTokenChar(value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTokenChar reports whether ch is a token-char of RFC 8866.
+func isTokenChar(ch byte) bool {
+	switch {
+	case ch <= ' ', ch >= 0x7f:
+		return false
+	case ch == '"', ch == '(', ch == ')', ch == ',', ch == '/',
+		ch == ':', ch == ';', ch == '<', ch == '=', ch == '>',
+		ch == '?', ch == '@', ch == '[', ch == '\\', ch == ']':
+		return false
+	}
+
+	return true
+}
+
+func isDomainName(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9', ch == '-', ch == '.':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
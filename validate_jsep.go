@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SDPType is the role of a session description in an offer/answer exchange.
+type SDPType int
+
+const (
+	// SDPTypeOffer is a session description sent as an offer.
+	SDPTypeOffer SDPType = iota + 1
+
+	// SDPTypeAnswer is a session description sent as an answer.
+	SDPTypeAnswer
+)
+
+func (t SDPType) String() string {
+	switch t {
+	case SDPTypeOffer:
+		return "offer"
+	case SDPTypeAnswer:
+		return "answer"
+	default:
+		return "Unknown"
+	}
+}
+
+var (
+	// ErrJSEPMissingMid indicates a media description without "a=mid".
+	ErrJSEPMissingMid = errors.New("sdp: jsep: missing mid")
+
+	// ErrJSEPDuplicateMid indicates an "a=mid" value used by more than one
+	// media description.
+	ErrJSEPDuplicateMid = errors.New("sdp: jsep: duplicate mid")
+
+	// ErrJSEPUnknownBundleMid indicates a BUNDLE group that names a mid no
+	// media description has.
+	ErrJSEPUnknownBundleMid = errors.New("sdp: jsep: unknown mid in BUNDLE group")
+
+	// ErrJSEPMissingICECredentials indicates a media description without
+	// "a=ice-ufrag" or "a=ice-pwd".
+	ErrJSEPMissingICECredentials = errors.New("sdp: jsep: missing ICE credentials")
+
+	// ErrJSEPMissingFingerprint indicates a media description without
+	// "a=fingerprint".
+	ErrJSEPMissingFingerprint = errors.New("sdp: jsep: missing fingerprint")
+
+	// ErrJSEPInvalidSetup indicates a missing "a=setup" or a value that is
+	// not allowed for the SDPType.
+	ErrJSEPInvalidSetup = errors.New("sdp: jsep: invalid setup")
+
+	// ErrJSEPMissingRTCPMux indicates an RTP media description without
+	// "a=rtcp-mux".
+	ErrJSEPMissingRTCPMux = errors.New("sdp: jsep: missing rtcp-mux")
+
+	// ErrJSEPInvalidMsid indicates an "a=msid" value that is not of the
+	// form <stream id> [<track id>].
+	ErrJSEPInvalidMsid = errors.New("sdp: jsep: invalid msid")
+)
+
+// ValidateJSEP checks the semantic rules JSEP (RFC 9429) places on a WebRTC
+// offer or answer:
+//
+//   - every media description has a unique "a=mid"
+//   - every mid in "a=group:BUNDLE" exists
+//   - every media description has ICE credentials, a fingerprint and a
+//     setup role, at media or session level, or inherits them from the
+//     BUNDLE-tagged media description of its group, see BundleTag
+//   - "a=setup" is "actpass" in offers and "active" or "passive" in answers
+//   - every RTP media description has "a=rtcp-mux"
+//   - every "a=msid" is of the form <stream id> [<track id>]
+//
+// Rejected media descriptions, with port 0 and no "a=bundle-only", are only
+// checked for their mid. ValidateJSEP returns nil if the description
+// follows the rules, otherwise ValidationErrors wrapping the ErrJSEP
+// errors. It does not check the grammar, see SessionDescription.Validate.
+func ValidateJSEP(sd *SessionDescription, sdpType SDPType) error {
+	v := validator{media: -1}
+
+	mids := map[string]int{}
+	for i, md := range sd.MediaDescriptions {
+		v.media = i
+		mid, ok := md.Attribute(AttrKeyMID)
+		if !ok || mid == "" {
+			v.add(AttrKeyMID, ErrJSEPMissingMid)
+
+			continue
+		}
+		if other, ok := mids[mid]; ok {
+			v.add(AttrKeyMID, fmt.Errorf("%w `%v`, also used by media %d", ErrJSEPDuplicateMid, mid, other))
+
+			continue
+		}
+		mids[mid] = i
+	}
+
+	v.media = -1
+	for _, a := range sd.Attributes {
+		if a.Key != AttrKeyGroup {
+			continue
+		}
+		fields := strings.Fields(a.Value)
+		if len(fields) == 0 || fields[0] != SemanticTokenBundle {
+			continue
+		}
+
+		for _, mid := range fields[1:] {
+			if _, ok := mids[mid]; !ok {
+				v.add(AttrKeyGroup, fmt.Errorf("%w `%v`", ErrJSEPUnknownBundleMid, mid))
+			}
+		}
+	}
+
+	for i, md := range sd.MediaDescriptions {
+		v.media = i
+		if md.IsRejected() {
+			continue
+		}
+
+		transport := md
+		if mid, ok := md.Attribute(AttrKeyMID); ok {
+			if tag := sd.BundleTag(mid); tag != nil {
+				transport = tag
+			}
+		}
+		lookup := func(key string) (string, bool) {
+			for _, d := range []*MediaDescription{md, transport} {
+				if value, ok := d.Attribute(key); ok {
+					return value, true
+				}
+			}
+
+			return sd.Attribute(key)
+		}
+
+		_, hasUfrag := lookup(AttrKeyICEUfrag)
+		_, hasPwd := lookup(AttrKeyICEPwd)
+		if !hasUfrag || !hasPwd {
+			v.add(AttrKeyICEUfrag, ErrJSEPMissingICECredentials)
+		}
+		if _, ok := lookup(AttrKeyFingerprint); !ok {
+			v.add(AttrKeyFingerprint, ErrJSEPMissingFingerprint)
+		}
+		v.setup(lookup, sdpType)
+
+		rtcpMux := hasAttribute(md.Attributes, AttrKeyRTCPMux) || hasAttribute(transport.Attributes, AttrKeyRTCPMux)
+		if isRTP(md) && !rtcpMux {
+			v.add(AttrKeyRTCPMux, ErrJSEPMissingRTCPMux)
+		}
+
+		for _, a := range md.Attributes {
+			if a.Key == AttrKeyMsid && !isValidMsid(a.Value) {
+				v.add(AttrKeyMsid, fmt.Errorf("%w `%v`", ErrJSEPInvalidMsid, a.Value))
+			}
+		}
+	}
+
+	return v.result()
+}
+
+func (v *validator) setup(lookup func(string) (string, bool), sdpType SDPType) {
+	setup, ok := lookup(AttrKeyConnectionSetup)
+	switch {
+	case !ok:
+		v.add(AttrKeyConnectionSetup, fmt.Errorf("%w: missing", ErrJSEPInvalidSetup))
+	case sdpType == SDPTypeOffer && setup != "actpass":
+		v.add(AttrKeyConnectionSetup, fmt.Errorf("%w `%v` in %v", ErrJSEPInvalidSetup, setup, sdpType))
+	case sdpType == SDPTypeAnswer && setup != "active" && setup != "passive":
+		v.add(AttrKeyConnectionSetup, fmt.Errorf("%w `%v` in %v", ErrJSEPInvalidSetup, setup, sdpType))
+	}
+}
+
+func isRTP(md *MediaDescription) bool {
+	for _, proto := range md.MediaName.Protos {
+		if proto == "RTP" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidMsid checks for msid-value = msid-id [ SP msid-appdata ] of
+// RFC 8830, where both are 1*64 token-char.
+func isValidMsid(value string) bool {
+	fields := strings.Split(value, " ")
+	if len(fields) > 2 {
+		return false
+	}
+
+	for _, field := range fields {
+		if len(field) > 64 || !isToken(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasAttribute(attributes []Attribute, key string) bool {
+	for _, a := range attributes {
+		if a.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsepOfferSDP = "v=0\r\n" +
+	"o=- 4596489990601351948 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0 1 2\r\n" +
+	"a=msid-semantic: WMS\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=ice-ufrag:ETEn\r\n" +
+	"a=ice-pwd:OtSK0WpNtpUjkY4+86js7ZQl\r\n" +
+	"a=fingerprint:sha-256 19:E2:1C:3B:4B:9F:81:E6:B8:5C:F4:A5:A8:D8:73:04:" +
+	"BB:05:2F:70:9F:04:A9:0E:05:E9:26:33:E8:70:88:A2\r\n" +
+	"a=setup:actpass\r\n" +
+	"a=mid:0\r\n" +
+	"a=sendrecv\r\n" +
+	"a=msid:stream0 track0\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"m=video 0 UDP/TLS/RTP/SAVPF 96\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=bundle-only\r\n" +
+	"a=mid:1\r\n" +
+	"a=sendrecv\r\n" +
+	"a=msid:- track1\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n" +
+	"m=application 9 UDP/DTLS/SCTP webrtc-datachannel\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:2\r\n" +
+	"a=sctp-port:5000\r\n"
+
+func TestValidateJSEPValid(t *testing.T) {
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(jsepOfferSDP))
+	assert.NoError(t, ValidateJSEP(sd, SDPTypeOffer))
+
+	answer := strings.ReplaceAll(jsepOfferSDP, "a=setup:actpass", "a=setup:passive")
+	sd = &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(answer))
+	assert.NoError(t, ValidateJSEP(sd, SDPTypeAnswer))
+
+	// The bundle-only video is listed first, the audio still carries the
+	// transport.
+	reordered := strings.Replace(jsepOfferSDP, "a=group:BUNDLE 0 1 2", "a=group:BUNDLE 1 0 2", 1)
+	sd = &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(reordered))
+	assert.Same(t, sd.MediaDescriptions[0], sd.BundleTag("1"))
+	assert.NoError(t, ValidateJSEP(sd, SDPTypeOffer))
+}
+
+func TestValidateJSEPFindings(t *testing.T) {
+	value := strings.NewReplacer(
+		"a=group:BUNDLE 0 1 2", "a=group:BUNDLE 0 1 3",
+		"a=mid:2", "a=mid:1",
+		"a=rtcp-mux\r\n", "",
+		"a=msid:- track1", "a=msid:a b c",
+	).Replace(jsepOfferSDP) +
+		"m=audio 9 UDP/TLS/RTP/SAVPF 0\r\n" +
+		"c=IN IP4 0.0.0.0\r\n" +
+		"a=setup:active\r\n" +
+		"a=rtcp-mux\r\n" +
+		"m=audio 0 UDP/TLS/RTP/SAVPF 0\r\n" +
+		"c=IN IP4 0.0.0.0\r\n" +
+		"a=mid:4\r\n"
+
+	sd := &SessionDescription{}
+	assert.NoError(t, sd.UnmarshalString(value))
+
+	err := ValidateJSEP(sd, SDPTypeOffer)
+	var errs ValidationErrors
+	if !assert.True(t, errors.As(err, &errs)) {
+		return
+	}
+
+	type finding struct {
+		Field      string
+		MediaIndex int
+		Err        error
+	}
+
+	actual := make([]finding, len(errs))
+	for i, e := range errs {
+		actual[i] = finding{e.Field, e.MediaIndex, errors.Unwrap(e.Err)}
+		if actual[i].Err == nil {
+			actual[i].Err = e.Err
+		}
+	}
+
+	assert.Equal(t, []finding{
+		{"mid", 2, ErrJSEPDuplicateMid},
+		{"mid", 3, ErrJSEPMissingMid},
+		{"group", -1, ErrJSEPUnknownBundleMid},
+		{"rtcp-mux", 0, ErrJSEPMissingRTCPMux},
+		{"rtcp-mux", 1, ErrJSEPMissingRTCPMux},
+		{"msid", 1, ErrJSEPInvalidMsid},
+		{"ice-ufrag", 3, ErrJSEPMissingICECredentials},
+		{"fingerprint", 3, ErrJSEPMissingFingerprint},
+		{"setup", 3, ErrJSEPInvalidSetup},
+	}, actual)
+
+	assert.Contains(t, err.Error(), "sdp: jsep: invalid setup `active` in offer (media 3: setup)")
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateParsed(t *testing.T) {
+	for _, value := range []string{
+		CanonicalUnmarshalSDP,
+		SessionConnectionInformationSDP,
+		BaseSDP + "c=IN IP6 FF15::101/3\r\n" + "t=0 0\r\n" + "m=audio 9 RTP/AVP 0\r\n",
+		TimeZonesSDP2,
+	} {
+		sd := &SessionDescription{}
+		assert.NoError(t, sd.UnmarshalString(value))
+		assert.NoError(t, sd.Validate())
+	}
+}
+
+func TestValidateJSEP(t *testing.T) {
+	sd, err := NewJSEPSessionDescription(false)
+	assert.NoError(t, err)
+
+	sd.WithMedia(NewJSEPMediaDescription("audio", nil).WithCodec(111, "opus", 48000, 2, ""))
+	assert.NoError(t, sd.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	rangeZero := 0
+	sd := &SessionDescription{
+		Version: 1,
+		Origin: Origin{
+			Username:       "jane doe",
+			NetworkType:    "IN",
+			AddressType:    "IP6",
+			UnicastAddress: "10.0.0.1",
+		},
+		ConnectionInformation: &ConnectionInformation{
+			NetworkType: "IN",
+			AddressType: "IP4",
+			Address:     &Address{Address: "224.2.17.12"},
+		},
+		Bandwidth: []Bandwidth{{Type: "A:S", Bandwidth: 10}},
+		Attributes: []Attribute{
+			NewAttribute("tool", "line\r\nbreak"),
+			NewPropertyAttribute("bad key"),
+		},
+		MediaDescriptions: []*MediaDescription{
+			{
+				MediaName: MediaName{
+					Media:  "audio",
+					Port:   RangedPort{Value: 70000, Range: &rangeZero},
+					Protos: []string{"RTP/AVP"},
+				},
+				ConnectionInformation: &ConnectionInformation{
+					NetworkType: "IN",
+					AddressType: "IP4",
+					Address:     &Address{Address: "203.0.113.1/127"},
+				},
+			},
+		},
+	}
+
+	err := sd.Validate()
+	var errs ValidationErrors
+	if !assert.True(t, errors.As(err, &errs)) {
+		return
+	}
+
+	type finding struct {
+		Field      string
+		MediaIndex int
+		Err        error
+	}
+
+	actual := make([]finding, len(errs))
+	for i, e := range errs {
+		for _, kind := range []error{
+			ErrMissingField, ErrInvalidToken, ErrInvalidAddress, ErrInvalidPortValue, ErrInvalidValue,
+		} {
+			if errors.Is(e, kind) {
+				actual[i] = finding{e.Field, e.MediaIndex, kind}
+
+				break
+			}
+		}
+	}
+
+	assert.Equal(t, []finding{
+		{"Version", -1, ErrInvalidValue},
+		{"Origin.Username", -1, ErrInvalidValue},
+		{"Origin.UnicastAddress", -1, ErrInvalidAddress},
+		{"SessionName", -1, ErrMissingField},
+		{"ConnectionInformation.Address.TTL", -1, ErrMissingField},
+		{"Bandwidth[0].Type", -1, ErrInvalidToken},
+		{"TimeDescriptions", -1, ErrMissingField},
+		{"Attributes[0].Value", -1, ErrInvalidValue},
+		{"Attributes[1].Key", -1, ErrInvalidToken},
+		{"MediaName.Port", 0, ErrInvalidPortValue},
+		{"MediaName.Port.Range", 0, ErrInvalidValue},
+		{"MediaName.Protos[0]", 0, ErrInvalidToken},
+		{"MediaName.Formats", 0, ErrMissingField},
+		{"ConnectionInformation.Address.TTL", 0, ErrInvalidAddress},
+	}, actual)
+
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Contains(t, err.Error(), "sdp: invalid port value `70000` (media 0: MediaName.Port)")
+}
+
+func TestValidateMediaDescription(t *testing.T) {
+	md := NewJSEPMediaDescription("video", nil).WithCodec(96, "VP8", 90000, 0, "")
+	assert.NoError(t, md.Validate())
+
+	md.MediaName.Media = ""
+	err := md.Validate()
+	assert.ErrorIs(t, err, ErrMissingField)
+	assert.Equal(t, "sdp: missing required field (media 0: MediaName.Media)", err.Error())
+}